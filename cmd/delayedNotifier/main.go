@@ -1,18 +1,44 @@
 package main
 
 import (
+	"DelayedNotifier/internal/blob/local"
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/channel/discord"
+	"DelayedNotifier/internal/channel/email"
+	"DelayedNotifier/internal/channel/slack"
+	"DelayedNotifier/internal/channel/webhook"
 	"DelayedNotifier/internal/config"
+	"DelayedNotifier/internal/escalator"
+	"DelayedNotifier/internal/http-server/handlers/admin/listDeadLetters"
+	"DelayedNotifier/internal/http-server/handlers/admin/purgeDeadLetters"
+	"DelayedNotifier/internal/http-server/handlers/admin/reconcileReport"
+	"DelayedNotifier/internal/http-server/handlers/admin/replayDeadLetter"
+	"DelayedNotifier/internal/http-server/handlers/admin/replayDeadLetters"
+	"DelayedNotifier/internal/http-server/handlers/admin/runReconcile"
 	"DelayedNotifier/internal/http-server/handlers/notify/createNotify"
 	"DelayedNotifier/internal/http-server/handlers/notify/deleteNotify"
+	"DelayedNotifier/internal/http-server/handlers/notify/getHistory"
 	"DelayedNotifier/internal/http-server/handlers/notify/getStatus"
+	"DelayedNotifier/internal/http-server/handlers/recipients/createRecipient"
+	"DelayedNotifier/internal/http-server/handlers/recipients/deactivateRecipient"
+	"DelayedNotifier/internal/http-server/handlers/recipients/listRecipients"
+	"DelayedNotifier/internal/http-server/handlers/recipients/updateRecipient"
+	"DelayedNotifier/internal/http-server/handlers/series/cancelSeries"
 	"DelayedNotifier/internal/http-server/middleware/mwlogger"
+	"DelayedNotifier/internal/janitor"
 	"DelayedNotifier/internal/lib/logger/handlers/slogpretty"
 	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/outbox"
 	"DelayedNotifier/internal/rabbitMQ/broker"
+	"DelayedNotifier/internal/reconciler"
+	"DelayedNotifier/internal/scheduler"
 	"DelayedNotifier/internal/service"
 	"DelayedNotifier/internal/storage/postgres"
 	"DelayedNotifier/internal/telegram/notifier"
+	"DelayedNotifier/internal/telegram/ratelimit"
+	"DelayedNotifier/internal/telegram/receiver"
 	"DelayedNotifier/internal/worker"
+	"context"
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -44,7 +70,7 @@ func main() {
 	}
 
 	rabbitURL := fmt.Sprintf("amqp://%s:%s@%s:%d/", cfg.Rabbit.User, cfg.Rabbit.Password, cfg.Rabbit.Host, cfg.Rabbit.Port)
-	mqBroker, err := broker.New(rabbitURL)
+	mqBroker, err := broker.New(rabbitURL, cfg.Rabbit.Publisher, log)
 	if err != nil {
 		log.Error("failed to init RabbitMQ broker", sl.Err(err))
 		os.Exit(1)
@@ -55,22 +81,76 @@ func main() {
 		os.Exit(1)
 	}
 
-	tgNotifier, err := notifier.New(cfg.TGToken)
+	log.Info("RabbitMQ delayed delivery configured", slog.Bool("delayed_plugin", mqBroker.DelayedPlugin()))
+
+	var limiter notifier.Limiter
+	if cfg.Telegram.RateLimit.Enabled {
+		limiter = ratelimit.New(storage.Redis(), cfg.Telegram.RateLimit)
+	}
+
+	tgNotifier, err := notifier.New(cfg.TGToken, cfg.Telegram, limiter)
 	if err != nil {
 		log.Error("failed to init Telegram notifier", sl.Err(err))
 		os.Exit(1)
 	}
 
-	appService := service.New(storage, mqBroker, cfg, tgNotifier)
+	channels := channel.NewRegistry(
+		notifier.Name,
+		tgNotifier,
+		webhook.New(cfg.Webhook),
+		slack.New(cfg.Slack),
+		discord.New(cfg.Discord),
+	)
+
+	if cfg.Email.Host != "" {
+		emailSender, err := email.New(cfg.Email)
+		if err != nil {
+			log.Error("failed to init email channel", sl.Err(err))
+			os.Exit(1)
+		}
+		channels.Register(emailSender)
+	}
+
+	log.Info("Delivery channels configured", slog.Any("channels", channels.Names()))
 
-	msgs, err := mqBroker.Consume(cfg.Rabbit.QueueName)
+	blobs, err := local.New(cfg.Attachments.Dir)
 	if err != nil {
-		log.Error("failed to consume from RabbitMQ", sl.Err(err))
+		log.Error("failed to init attachments store", sl.Err(err))
 		os.Exit(1)
 	}
 
-	workerHandler := worker.New(appService, log)
-	go workerHandler.Start(msgs)
+	appService := service.New(storage, mqBroker, cfg, channels, blobs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	appReconciler := reconciler.New(appService, log, cfg.Reconciler.Interval)
+
+	attachmentsJanitor := janitor.New(appService, log, cfg.Attachments.CleanupInterval)
+	go attachmentsJanitor.Start(ctx)
+
+	appEscalator := escalator.New(appService, log, cfg.Escalation.PollInterval)
+	go appEscalator.Start(ctx)
+
+	switch cfg.Scheduler.Mode {
+	case config.SchedulerPostgres:
+		poller := scheduler.New(appService, log, cfg.Scheduler.PollInterval)
+		go poller.Start(ctx)
+	default:
+		msgs, err := mqBroker.Consume(cfg.Rabbit.QueueName, cfg.Rabbit.Prefetch)
+		if err != nil {
+			log.Error("failed to consume from RabbitMQ", sl.Err(err))
+			os.Exit(1)
+		}
+
+		workerHandler := worker.New(appService, log, cfg.Rabbit.Workers, cfg.Rabbit.Prefetch)
+		go workerHandler.Start(msgs)
+
+		relay := outbox.New(appService, log, cfg.Outbox.PollInterval)
+		go relay.Start(ctx)
+
+		go appReconciler.Start(ctx)
+	}
 
 	router := chi.NewRouter()
 
@@ -87,9 +167,45 @@ func main() {
 		http.ServeFile(w, r, "./static/index.html")
 	})
 
-	router.Post("/notify", createNotify.New(log, appService))
+	router.Post("/notify", createNotify.New(log, appService, cfg.Attachments.MaxSize))
 	router.Get("/notify/{id}", getStatus.New(log, appService))
+	router.Get("/notify/{id}/history", getHistory.New(log, appService))
 	router.Delete("/notify/{id}", deleteNotify.New(log, appService))
+	router.Delete("/series/{id}", cancelSeries.New(log, appService))
+
+	botName := tgNotifier.Bot().Self.UserName
+	router.Post("/recipients", createRecipient.New(log, appService, botName))
+	router.Get("/recipients", listRecipients.New(log, appService))
+	router.Patch("/recipients/{id}", updateRecipient.New(log, appService, botName))
+	router.Delete("/recipients/{id}", deactivateRecipient.New(log, appService))
+
+	switch cfg.Telegram.Updates {
+	case config.UpdatesWebhook:
+		updatesReceiver := receiver.New(tgNotifier.Bot(), appService, log, cfg.Telegram)
+		webhookPath, err := updatesReceiver.SetWebhook()
+		if err != nil {
+			log.Error("failed to set Telegram webhook", sl.Err(err))
+			os.Exit(1)
+		}
+		router.Post(webhookPath, updatesReceiver.Webhook())
+	case config.UpdatesOff:
+	default:
+		updatesReceiver := receiver.New(tgNotifier.Bot(), appService, log, cfg.Telegram)
+		go updatesReceiver.Poll(ctx)
+	}
+
+	router.Route("/admin", func(r chi.Router) {
+		// В режиме postgres уведомления не проходят через RabbitMQ и реконсилятор не запущен.
+		if cfg.Scheduler.Mode != config.SchedulerPostgres {
+			r.Get("/reconciler", reconcileReport.New(log, appReconciler))
+			r.Post("/reconciler/run", runReconcile.New(log, appReconciler))
+		}
+
+		r.Get("/dlq", listDeadLetters.New(log, appService))
+		r.Post("/dlq/replay", replayDeadLetters.New(log, appService))
+		r.Post("/dlq/{id}/replay", replayDeadLetter.New(log, appService))
+		r.Delete("/dlq", purgeDeadLetters.New(log, appService))
+	})
 
 	log.Info("starting server", slog.String("address", cfg.HTTPServer.Address))
 
@@ -112,6 +228,8 @@ func main() {
 
 	log.Info("server stopped", slog.String("signal", sign.String()))
 
+	cancel()
+
 	if err = storage.Close(); err != nil {
 		log.Error("failed to close database", slog.String("error", err.Error()))
 	}
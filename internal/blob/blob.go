@@ -0,0 +1,19 @@
+package blob
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Store хранит содержимое вложений по ключу. Реализация выбирается при запуске:
+// локальная файловая система или внешнее хранилище с тем же интерфейсом.
+type Store interface {
+	// Put сохраняет содержимое r под ключом key и возвращает число записанных байт.
+	Put(key string, r io.Reader) (int64, error)
+	// Open открывает содержимое для чтения; для отсутствующего ключа возвращает ErrNotFound.
+	Open(key string) (io.ReadCloser, error)
+	// Delete удаляет содержимое; отсутствующий ключ ошибкой не считается.
+	Delete(key string) error
+}
@@ -0,0 +1,100 @@
+package local
+
+import (
+	"DelayedNotifier/internal/blob"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// validKey не пропускает разделители путей и "..", чтобы ключ не выходил за пределы каталога.
+var validKey = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Store хранит вложения файлами в одном каталоге.
+type Store struct {
+	dir string
+}
+
+func New(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+// Put записывает содержимое во временный файл и переименовывает его, чтобы читатели
+// никогда не видели недописанный файл.
+func (s *Store) Put(key string, r io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create blob: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		_ = tmp.Close()
+		return 0, fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to save blob: %w", err)
+	}
+
+	return size, nil
+}
+
+func (s *Store) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, blob.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+
+	return f, nil
+}
+
+func (s *Store) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) path(key string) (string, error) {
+	if !validKey.MatchString(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(s.dir, key), nil
+}
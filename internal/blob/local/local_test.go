@@ -0,0 +1,43 @@
+package local
+
+import (
+	"DelayedNotifier/internal/blob"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_PutOpenDelete(t *testing.T) {
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	size, err := store.Put("report.pdf", strings.NewReader("%PDF-1.4"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), size)
+
+	r, err := store.Open("report.pdf")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "%PDF-1.4", string(content))
+
+	require.NoError(t, store.Delete("report.pdf"))
+	require.NoError(t, store.Delete("report.pdf"))
+
+	_, err = store.Open("report.pdf")
+	assert.ErrorIs(t, err, blob.ErrNotFound)
+}
+
+func TestStore_InvalidKey(t *testing.T) {
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "..", "../secret", "dir/file", ".hidden"} {
+		_, err = store.Put(key, strings.NewReader("x"))
+		assert.Error(t, err, key)
+	}
+}
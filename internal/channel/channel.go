@@ -0,0 +1,188 @@
+package channel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+var ErrUnknownChannel = errors.New("unknown channel")
+
+// Message — уведомление, подготовленное к отправке через канал.
+// RecipientID адресует чат Telegram, Address — адрес в остальных каналах (e-mail, URL и т.п.).
+// ParseMode, DisableWebPagePreview и DisableNotification учитывают только каналы, которые их поддерживают.
+// SentParts — число частей длинного сообщения, доставленных предыдущими попытками.
+// Attachment отправляют только каналы, которые умеют передавать файлы; остальные отправляют текст.
+type Message struct {
+	NotificationID        int64
+	RecipientID           int64
+	Address               string
+	Text                  string
+	ParseMode             string
+	DisableWebPagePreview bool
+	DisableNotification   bool
+	SentParts             int
+	Date                  time.Time
+	Metadata              json.RawMessage
+	Attachment            *Attachment
+}
+
+// Attachment — файл уведомления: ссылка URL или содержимое, которое читается через Open.
+type Attachment struct {
+	Kind        string
+	FileName    string
+	ContentType string
+	Size        int64
+	URL         string
+	Open        func() (io.ReadCloser, error)
+}
+
+// Channel доставляет уведомления получателю одним способом: Telegram, e-mail и т.д.
+type Channel interface {
+	// Name возвращает имя канала, которое указывается в поле channel запроса.
+	Name() string
+	// Validate проверяет, что сообщение можно отправить через канал, ещё при создании уведомления.
+	Validate(msg Message) error
+	Send(msg Message) error
+}
+
+// MultipartSender — канал, который отправляет длинное сообщение несколькими частями.
+// SendParts начинает с части msg.SentParts и возвращает идентификаторы доставленных частей,
+// в том числе когда отправка прервалась ошибкой.
+type MultipartSender interface {
+	SendParts(msg Message) ([]int64, error)
+}
+
+// PartialError означает, что часть сообщения уже доставлена, а следующая — нет.
+type PartialError struct {
+	Sent  int
+	Total int
+	Err   error
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("sent %d of %d parts: %v", e.Sent, e.Total, e.Err)
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// RateLimitError означает, что канал ограничил частоту отправки: повторять стоит не раньше чем через RetryAfter.
+type RateLimitError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RateLimitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// PermanentError означает, что получатель недоступен в канале и повторы не помогут:
+// например, пользователь заблокировал бота или чат удалён.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Registry выбирает канал доставки по имени, сохранённому в уведомлении.
+type Registry struct {
+	channels    map[string]Channel
+	defaultName string
+}
+
+// NewRegistry создаёт реестр; defaultName используется для уведомлений без явного канала.
+func NewRegistry(defaultName string, channels ...Channel) *Registry {
+	r := &Registry{
+		channels:    make(map[string]Channel, len(channels)),
+		defaultName: defaultName,
+	}
+
+	for _, ch := range channels {
+		r.Register(ch)
+	}
+
+	return r
+}
+
+func (r *Registry) Register(ch Channel) {
+	r.channels[ch.Name()] = ch
+}
+
+// Resolve возвращает имя канала: name или канал по умолчанию, если name пустое.
+func (r *Registry) Resolve(name string) string {
+	if name == "" {
+		return r.defaultName
+	}
+
+	return name
+}
+
+func (r *Registry) Get(name string) (Channel, error) {
+	name = r.Resolve(name)
+
+	ch, ok := r.channels[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownChannel, name)
+	}
+
+	return ch, nil
+}
+
+// Names возвращает имена зарегистрированных каналов в алфавитном порядке.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.channels))
+	for name := range r.channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (r *Registry) Validate(name string, msg Message) error {
+	ch, err := r.Get(name)
+	if err != nil {
+		return err
+	}
+
+	return ch.Validate(msg)
+}
+
+func (r *Registry) Send(name string, msg Message) error {
+	ch, err := r.Get(name)
+	if err != nil {
+		return err
+	}
+
+	return ch.Send(msg)
+}
+
+// SendParts отправляет сообщение и возвращает идентификаторы созданных сообщений,
+// если канал их сообщает; остальные каналы отправляют сообщение целиком через Send.
+func (r *Registry) SendParts(name string, msg Message) ([]int64, error) {
+	ch, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if sender, ok := ch.(MultipartSender); ok {
+		return sender.SendParts(msg)
+	}
+
+	return nil, ch.Send(msg)
+}
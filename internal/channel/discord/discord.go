@@ -0,0 +1,144 @@
+package discord
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const Name = "discord"
+
+const (
+	// maxDescription — ограничение Discord на длину описания embed.
+	maxDescription = 4096
+	embedColor     = 0x5865F2
+)
+
+type footer struct {
+	Text string `json:"text"`
+}
+
+type embed struct {
+	Title       string  `json:"title,omitempty"`
+	Description string  `json:"description"`
+	Color       int     `json:"color"`
+	Timestamp   string  `json:"timestamp,omitempty"`
+	Footer      *footer `json:"footer,omitempty"`
+}
+
+type allowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+// Payload — сообщение для webhook Discord. Упоминания отключены,
+// чтобы текст уведомления не мог сделать @everyone.
+type Payload struct {
+	Username        string          `json:"username,omitempty"`
+	Embeds          []embed         `json:"embeds"`
+	AllowedMentions allowedMentions `json:"allowed_mentions"`
+}
+
+// Sender публикует уведомления в Discord через webhook канала.
+// URL берётся из поля address уведомления или из конфигурации.
+type Sender struct {
+	client *http.Client
+	// addressClient отправляет на URL из поля address: его задаёт клиент API,
+	// поэтому без allowPrivate соединения с непубличными адресами запрещены.
+	addressClient *http.Client
+	allowPrivate  bool
+	webhookURL    string
+	username      string
+}
+
+func New(cfg config.Discord) *Sender {
+	s := &Sender{
+		client:        &http.Client{Timeout: cfg.Timeout},
+		addressClient: channel.PublicClient(cfg.Timeout),
+		allowPrivate:  cfg.AllowPrivate,
+		webhookURL:    cfg.WebhookURL,
+		username:      cfg.Username,
+	}
+	if cfg.AllowPrivate {
+		s.addressClient = s.client
+	}
+
+	return s
+}
+
+func (s *Sender) Name() string {
+	return Name
+}
+
+func (s *Sender) Validate(msg channel.Message) error {
+	_, _, err := s.targetURL(msg)
+	return err
+}
+
+func (s *Sender) Send(msg channel.Message) error {
+	target, client, err := s.targetURL(msg)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(s.NewPayload(msg))
+	if err != nil {
+		return fmt.Errorf("failed to encode Discord message: %w", err)
+	}
+
+	if err = channel.PostJSON(client, target, body, nil); err != nil {
+		return fmt.Errorf("failed to send message to Discord: %w", err)
+	}
+
+	return nil
+}
+
+// NewPayload оформляет уведомление как embed с датой отправки в поле timestamp,
+// которую Discord показывает в часовом поясе читателя.
+func (s *Sender) NewPayload(msg channel.Message) Payload {
+	e := embed{
+		Title:       "Напоминание",
+		Description: channel.Truncate(msg.Text, maxDescription),
+		Color:       embedColor,
+		Footer:      &footer{Text: "Delayed Notifier"},
+	}
+
+	if !msg.Date.IsZero() {
+		e.Timestamp = msg.Date.UTC().Format(time.RFC3339)
+	}
+
+	return Payload{
+		Username:        s.username,
+		Embeds:          []embed{e},
+		AllowedMentions: allowedMentions{Parse: []string{}},
+	}
+}
+
+// targetURL возвращает URL из уведомления или, если он не указан, URL из конфигурации,
+// и клиент, которым на него можно отправлять.
+func (s *Sender) targetURL(msg channel.Message) (string, *http.Client, error) {
+	if msg.Address == "" {
+		if s.webhookURL == "" {
+			return "", nil, fmt.Errorf("discord webhook URL is not set")
+		}
+
+		if err := channel.ValidateURL(s.webhookURL); err != nil {
+			return "", nil, fmt.Errorf("invalid Discord webhook URL: %w", err)
+		}
+
+		return s.webhookURL, s.client, nil
+	}
+
+	validate := channel.ValidatePublicURL
+	if s.allowPrivate {
+		validate = channel.ValidateURL
+	}
+
+	if err := validate(msg.Address); err != nil {
+		return "", nil, fmt.Errorf("invalid Discord webhook URL: %w", err)
+	}
+
+	return msg.Address, s.addressClient, nil
+}
@@ -0,0 +1,97 @@
+package discord
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_Send(t *testing.T) {
+	var got map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := New(config.Discord{WebhookURL: srv.URL, Username: "Notifier", Timeout: time.Second})
+
+	err := s.Send(channel.Message{Text: "Релиз в 18:00 @everyone", Date: time.Date(2025, 8, 9, 15, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+
+	expected := `{
+		"username": "Notifier",
+		"embeds": [{
+			"title": "Напоминание",
+			"description": "Релиз в 18:00 @everyone",
+			"color": 5793266,
+			"timestamp": "2025-08-09T15:00:00Z",
+			"footer": {"text": "Delayed Notifier"}
+		}],
+		"allowed_mentions": {"parse": []}
+	}`
+	raw, _ := json.Marshal(got)
+	assert.JSONEq(t, expected, string(raw))
+}
+
+func TestSender_SendRateLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 1.5}`))
+	}))
+	defer srv.Close()
+
+	s := New(config.Discord{WebhookURL: srv.URL, Timeout: time.Second})
+
+	err := s.Send(channel.Message{Text: "Test"})
+	assert.ErrorContains(t, err, "status 429")
+}
+
+func TestSender_PrivateAddress(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := New(config.Discord{Timeout: time.Second})
+
+	for _, address := range []string{srv.URL, "http://192.168.1.1/hook", "http://[::1]/hook"} {
+		assert.ErrorIs(t, s.Validate(channel.Message{Address: address}), channel.ErrPrivateAddress, address)
+	}
+
+	err := s.Send(channel.Message{Address: srv.URL, Text: "Test"})
+	assert.ErrorIs(t, err, channel.ErrPrivateAddress)
+	assert.False(t, called)
+
+	s = New(config.Discord{Timeout: time.Second, AllowPrivate: true})
+	require.NoError(t, s.Send(channel.Message{Address: srv.URL, Text: "Test"}))
+	assert.True(t, called)
+}
+
+func TestSender_Validate(t *testing.T) {
+	assert.Error(t, New(config.Discord{}).Validate(channel.Message{}))
+	assert.Error(t, New(config.Discord{}).Validate(channel.Message{Address: "discord.com/api/webhooks/1"}))
+	assert.NoError(t, New(config.Discord{WebhookURL: "https://discord.com/api/webhooks/1/x"}).Validate(channel.Message{}))
+}
+
+func TestNewPayload_TruncatesLongText(t *testing.T) {
+	s := New(config.Discord{})
+	p := s.NewPayload(channel.Message{Text: strings.Repeat("я", maxDescription+1)})
+
+	assert.Equal(t, maxDescription, utf8.RuneCountInString(p.Embeds[0].Description))
+	assert.Empty(t, p.Embeds[0].Timestamp)
+}
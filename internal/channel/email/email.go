@@ -0,0 +1,138 @@
+package email
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+const Name = "email"
+
+// Sender отправляет уведомления письмами через SMTP-сервер.
+type Sender struct {
+	host    string
+	port    int
+	auth    smtp.Auth
+	from    mail.Address
+	subject string
+	timeout time.Duration
+}
+
+func New(cfg config.Email) (*Sender, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", cfg.From, err)
+	}
+
+	s := &Sender{
+		host:    cfg.Host,
+		port:    cfg.Port,
+		from:    *from,
+		subject: cfg.Subject,
+		timeout: cfg.Timeout,
+	}
+
+	if cfg.Username != "" {
+		s.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return s, nil
+}
+
+func (s *Sender) Name() string {
+	return Name
+}
+
+func (s *Sender) Validate(msg channel.Message) error {
+	if _, err := mail.ParseAddress(msg.Address); err != nil {
+		return fmt.Errorf("invalid email address %q", msg.Address)
+	}
+
+	return nil
+}
+
+func (s *Sender) Send(msg channel.Message) error {
+	to, err := mail.ParseAddress(msg.Address)
+	if err != nil {
+		return fmt.Errorf("invalid email address %q: %w", msg.Address, err)
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(s.host, strconv.Itoa(s.port)), s.timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(s.timeout))
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if s.auth != nil {
+		if err = client.Auth(s.auth); err != nil {
+			return fmt.Errorf("failed to authenticate on SMTP server: %w", err)
+		}
+	}
+
+	if err = client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("SMTP server rejected sender: %w", err)
+	}
+	if err = client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("SMTP server rejected recipient: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err = w.Write(s.buildMessage(*to, msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage собирает письмо в формате RFC 5322 с телом в кодировке quoted-printable.
+func (s *Sender) buildMessage(to mail.Address, msg channel.Message) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", s.subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	if msg.NotificationID != 0 {
+		fmt.Fprintf(&buf, "Message-ID: <notification-%d.%d@%s>\r\n", msg.NotificationID, time.Now().UnixNano(), s.host)
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	_, _ = qp.Write([]byte(msg.Text))
+	_ = qp.Close()
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,185 @@
+package email
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"bufio"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedMail struct {
+	from string
+	to   []string
+	data []byte
+}
+
+// startSMTPServer запускает минимальный SMTP-сервер, который принимает письма
+// и отклоняет получателей из домена rejected.example.
+func startSMTPServer(t *testing.T) (host string, port int, received <-chan receivedMail) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	out := make(chan receivedMail, 1)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, out)
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+
+	return addr.IP.String(), addr.Port, out
+}
+
+func serveSMTP(conn net.Conn, out chan<- receivedMail) {
+	defer func() { _ = conn.Close() }()
+
+	r := textproto.NewReader(bufio.NewReader(conn))
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	var current receivedMail
+
+	reply("220 localhost ESMTP test")
+	for {
+		line, err := r.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(strings.Fields(line + " ")[0])
+		switch cmd {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case "HELO", "NOOP", "RSET":
+			reply("250 OK")
+		case "MAIL":
+			current = receivedMail{from: pathArg(line)}
+			reply("250 OK")
+		case "RCPT":
+			to := pathArg(line)
+			if strings.HasSuffix(to, "@rejected.example") {
+				reply("550 mailbox unavailable")
+				continue
+			}
+			current.to = append(current.to, to)
+			reply("250 OK")
+		case "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			data, err := r.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.data = data
+			out <- current
+			reply("250 OK queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+// pathArg извлекает адрес из команды вида "MAIL FROM:<a@b> BODY=8BITMIME".
+func pathArg(line string) string {
+	start, end := strings.Index(line, "<"), strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+
+	return line[start+1 : end]
+}
+
+func newTestSender(t *testing.T, host string, port int) *Sender {
+	t.Helper()
+
+	s, err := New(config.Email{
+		Host:    host,
+		Port:    port,
+		From:    "Notifier <noreply@example.com>",
+		Subject: "Напоминание",
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestSender_Send(t *testing.T) {
+	host, port, received := startSMTPServer(t)
+	s := newTestSender(t, host, port)
+
+	err := s.Send(channel.Message{NotificationID: 7, Address: "user@example.com", Text: "Привет! Пора на встречу."})
+	require.NoError(t, err)
+
+	var got receivedMail
+	select {
+	case got = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("mail was not received")
+	}
+
+	assert.Equal(t, "noreply@example.com", got.from)
+	assert.Equal(t, []string{"user@example.com"}, got.to)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(got.data)))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Напоминание", subject)
+	assert.Equal(t, "<user@example.com>", parsed.Header.Get("To"))
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err)
+	assert.Equal(t, "Привет! Пора на встречу.", strings.TrimSpace(string(body)))
+}
+
+func TestSender_SendRejectedRecipient(t *testing.T) {
+	host, port, _ := startSMTPServer(t)
+	s := newTestSender(t, host, port)
+
+	err := s.Send(channel.Message{Address: "user@rejected.example", Text: "Test"})
+	assert.ErrorContains(t, err, "rejected recipient")
+}
+
+func TestSender_SendServerUnavailable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().(*net.TCPAddr)
+	_ = ln.Close()
+
+	s := newTestSender(t, addr.IP.String(), addr.Port)
+
+	err = s.Send(channel.Message{Address: "user@example.com", Text: "Test"})
+	assert.Error(t, err)
+}
+
+func TestSender_Validate(t *testing.T) {
+	s := newTestSender(t, "localhost", 25)
+
+	assert.NoError(t, s.Validate(channel.Message{Address: "user@example.com"}))
+	assert.Error(t, s.Validate(channel.Message{Address: ""}))
+	assert.Error(t, s.Validate(channel.Message{Address: "not an address"}))
+}
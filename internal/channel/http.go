@@ -0,0 +1,138 @@
+package channel
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrPrivateAddress означает, что URL ведёт на loopback, частный, link-local или другой
+// непубличный адрес, куда нельзя отправлять запросы по адресу из уведомления.
+var ErrPrivateAddress = errors.New("private network addresses are not allowed")
+
+// PostJSON отправляет body POST-запросом и считает ошибкой любой ответ вне диапазона 2xx.
+// Используется каналами, которые доставляют уведомления через HTTP.
+func PostJSON(client *http.Client, target string, body []byte, header http.Header) error {
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Тело ответа дочитывается, чтобы соединение вернулось в пул. В ошибку оно не попадает:
+	// ошибка сохраняется в истории уведомления и видна через API.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ValidateURL проверяет, что raw — абсолютный URL со схемой http или https.
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q", raw)
+	}
+
+	return nil
+}
+
+// Truncate обрезает текст до limit символов, сохраняя целые руны, и ставит в конце многоточие.
+// Используется каналами, у API которых есть ограничение на длину текста.
+func Truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	runes := []rune(s)
+
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
+
+// ValidatePublicURL проверяет URL как ValidateURL и отклоняет хосты, которые разрешаются в непубличные адреса.
+// Если имя сейчас не разрешается, проверка откладывается до отправки: PublicClient проверит адрес при соединении.
+func ValidatePublicURL(raw string) error {
+	if err := ValidateURL(raw); err != nil {
+		return err
+	}
+
+	u, _ := url.Parse(raw)
+	ips, err := net.LookupIP(u.Hostname())
+	if err != nil {
+		return nil
+	}
+
+	for _, ip := range ips {
+		if !isPublic(ip) {
+			return fmt.Errorf("%w: %s", ErrPrivateAddress, u.Hostname())
+		}
+	}
+
+	return nil
+}
+
+// PublicClient возвращает HTTP-клиент, который соединяется только с публичными адресами.
+// Адрес проверяется при установке соединения, уже после разрешения имени и на каждом редиректе,
+// поэтому DNS, отвечающий по-разному при проверке и при отправке, не помогает обойти запрет.
+func PublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: publicOnly,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// Через прокси соединение устанавливалось бы с прокси, и проверка адреса назначения не сработала бы.
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !isPublic(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+
+	return nil
+}
+
+func isPublic(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
@@ -0,0 +1,35 @@
+package channel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicClient_RefusesPrivateAddress(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	// Проверка при соединении срабатывает, даже если URL не проходил ValidatePublicURL.
+	err := PostJSON(PublicClient(time.Second), srv.URL, []byte(`{}`), nil)
+	assert.ErrorIs(t, err, ErrPrivateAddress)
+	assert.False(t, called)
+}
+
+func TestPublicOnly(t *testing.T) {
+	assert.ErrorIs(t, publicOnly("tcp", "127.0.0.1:80", nil), ErrPrivateAddress)
+	assert.ErrorIs(t, publicOnly("tcp", "[fe80::1]:80", nil), ErrPrivateAddress)
+	assert.NoError(t, publicOnly("tcp", "93.184.216.34:443", nil))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate("short", 10))
+	assert.Equal(t, "привет…", Truncate("привет мир", 8))
+	assert.Equal(t, "ab…", Truncate("ab cd", 4))
+}
@@ -0,0 +1,137 @@
+package slack
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const Name = "slack"
+
+// maxSectionText — ограничение Slack на длину текста в блоке section.
+const maxSectionText = 3000
+
+type text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type block struct {
+	Type     string `json:"type"`
+	Text     *text  `json:"text,omitempty"`
+	Elements []text `json:"elements,omitempty"`
+}
+
+// Payload — сообщение для incoming webhook Slack (Block Kit).
+// Text используется как запасной вариант в уведомлениях и клиентах без поддержки блоков.
+type Payload struct {
+	Text   string  `json:"text"`
+	Blocks []block `json:"blocks"`
+}
+
+// Sender публикует уведомления в Slack через incoming webhook.
+// URL берётся из поля address уведомления или из конфигурации.
+type Sender struct {
+	client *http.Client
+	// addressClient отправляет на URL из поля address: его задаёт клиент API,
+	// поэтому без allowPrivate соединения с непубличными адресами запрещены.
+	addressClient *http.Client
+	allowPrivate  bool
+	webhookURL    string
+}
+
+func New(cfg config.Slack) *Sender {
+	s := &Sender{
+		client:        &http.Client{Timeout: cfg.Timeout},
+		addressClient: channel.PublicClient(cfg.Timeout),
+		allowPrivate:  cfg.AllowPrivate,
+		webhookURL:    cfg.WebhookURL,
+	}
+	if cfg.AllowPrivate {
+		s.addressClient = s.client
+	}
+
+	return s
+}
+
+func (s *Sender) Name() string {
+	return Name
+}
+
+func (s *Sender) Validate(msg channel.Message) error {
+	_, _, err := s.targetURL(msg)
+	return err
+}
+
+func (s *Sender) Send(msg channel.Message) error {
+	target, client, err := s.targetURL(msg)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(NewPayload(msg))
+	if err != nil {
+		return fmt.Errorf("failed to encode Slack message: %w", err)
+	}
+
+	if err = channel.PostJSON(client, target, body, nil); err != nil {
+		return fmt.Errorf("failed to send message to Slack: %w", err)
+	}
+
+	return nil
+}
+
+// NewPayload оформляет уведомление блоками: текст в mrkdwn и строка с датой отправки,
+// которую Slack показывает в часовом поясе читателя.
+func NewPayload(msg channel.Message) Payload {
+	p := Payload{
+		Text: msg.Text,
+		Blocks: []block{{
+			Type: "section",
+			Text: &text{Type: "mrkdwn", Text: channel.Truncate(msg.Text, maxSectionText)},
+		}},
+	}
+
+	if !msg.Date.IsZero() {
+		unix := strconv.FormatInt(msg.Date.Unix(), 10)
+		p.Blocks = append(p.Blocks, block{
+			Type: "context",
+			Elements: []text{{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf(":alarm_clock: <!date^%s^{date_short_pretty} {time}|%s>", unix, msg.Date.UTC().Format("2006-01-02 15:04 UTC")),
+			}},
+		})
+	}
+
+	return p
+}
+
+// targetURL возвращает URL из уведомления или, если он не указан, URL из конфигурации,
+// и клиент, которым на него можно отправлять.
+func (s *Sender) targetURL(msg channel.Message) (string, *http.Client, error) {
+	if msg.Address == "" {
+		if s.webhookURL == "" {
+			return "", nil, fmt.Errorf("slack webhook URL is not set")
+		}
+
+		if err := channel.ValidateURL(s.webhookURL); err != nil {
+			return "", nil, fmt.Errorf("invalid Slack webhook URL: %w", err)
+		}
+
+		return s.webhookURL, s.client, nil
+	}
+
+	validate := channel.ValidatePublicURL
+	if s.allowPrivate {
+		validate = channel.ValidateURL
+	}
+
+	if err := validate(msg.Address); err != nil {
+		return "", nil, fmt.Errorf("invalid Slack webhook URL: %w", err)
+	}
+
+	return msg.Address, s.addressClient, nil
+}
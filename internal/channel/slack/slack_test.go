@@ -0,0 +1,107 @@
+package slack
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_Send(t *testing.T) {
+	var got map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	s := New(config.Slack{WebhookURL: srv.URL, Timeout: time.Second})
+
+	err := s.Send(channel.Message{Text: "*Стендап* через 10 минут", Date: time.Date(2025, 8, 9, 6, 50, 0, 0, time.UTC)})
+	require.NoError(t, err)
+
+	expected := `{
+		"text": "*Стендап* через 10 минут",
+		"blocks": [
+			{"type": "section", "text": {"type": "mrkdwn", "text": "*Стендап* через 10 минут"}},
+			{"type": "context", "elements": [
+				{"type": "mrkdwn", "text": ":alarm_clock: <!date^1754722200^{date_short_pretty} {time}|2025-08-09 06:50 UTC>"}
+			]}
+		]
+	}`
+	raw, _ := json.Marshal(got)
+	assert.JSONEq(t, expected, string(raw))
+}
+
+func TestSender_SendAddressOverridesConfig(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	s := New(config.Slack{WebhookURL: "https://hooks.slack.invalid/services/x", Timeout: time.Second, AllowPrivate: true})
+
+	require.NoError(t, s.Send(channel.Message{Address: srv.URL, Text: "Test"}))
+	assert.True(t, called)
+}
+
+func TestSender_PrivateAddress(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	s := New(config.Slack{Timeout: time.Second})
+
+	for _, address := range []string{srv.URL, "http://10.0.0.5/hook", "http://169.254.169.254/latest/meta-data"} {
+		assert.ErrorIs(t, s.Validate(channel.Message{Address: address}), channel.ErrPrivateAddress, address)
+	}
+
+	err := s.Send(channel.Message{Address: srv.URL, Text: "Test"})
+	assert.ErrorIs(t, err, channel.ErrPrivateAddress)
+	assert.False(t, called)
+
+	// URL из конфигурации задаёт администратор, и на него ограничение не действует.
+	s = New(config.Slack{WebhookURL: srv.URL, Timeout: time.Second})
+	require.NoError(t, s.Send(channel.Message{Text: "Test"}))
+	assert.True(t, called)
+}
+
+func TestSender_SendError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_payload", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s := New(config.Slack{WebhookURL: srv.URL, Timeout: time.Second})
+
+	err := s.Send(channel.Message{Text: "Test"})
+	assert.ErrorContains(t, err, "status 400")
+	assert.NotContains(t, err.Error(), "invalid_payload")
+}
+
+func TestSender_Validate(t *testing.T) {
+	assert.Error(t, New(config.Slack{}).Validate(channel.Message{}))
+	assert.NoError(t, New(config.Slack{}).Validate(channel.Message{Address: "https://hooks.slack.com/services/x"}))
+	assert.NoError(t, New(config.Slack{WebhookURL: "https://hooks.slack.com/services/x"}).Validate(channel.Message{}))
+}
+
+func TestNewPayload_TruncatesLongText(t *testing.T) {
+	p := NewPayload(channel.Message{Text: strings.Repeat("я", maxSectionText+10)})
+
+	assert.Equal(t, maxSectionText, utf8.RuneCountInString(p.Blocks[0].Text.Text))
+	assert.Len(t, p.Blocks, 1)
+}
@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	Name = "webhook"
+
+	HeaderTimestamp      = "X-Webhook-Timestamp"
+	HeaderSignature      = "X-Webhook-Signature"
+	HeaderNotificationID = "X-Notification-ID"
+)
+
+// Payload — тело запроса, которое получает вебхук.
+type Payload struct {
+	ID          int64           `json:"id"`
+	RecipientID int64           `json:"recipient_id,omitempty"`
+	Text        string          `json:"text"`
+	ScheduledAt time.Time       `json:"scheduled_at"`
+	Metadata    json.RawMessage `json:"metadata,omitempty"`
+}
+
+// Sender доставляет уведомления POST-запросом с JSON на URL получателя.
+// Запрос подписывается HMAC-SHA256 от строки "<timestamp>.<body>" секретом из конфигурации.
+type Sender struct {
+	client *http.Client
+	// addressClient отправляет на URL из поля address: его задаёт клиент API,
+	// поэтому без allowPrivate соединения с непубличными адресами запрещены.
+	addressClient *http.Client
+	allowPrivate  bool
+	secret        []byte
+	recipients    map[string]string
+}
+
+func New(cfg config.Webhook) *Sender {
+	s := &Sender{
+		client:        &http.Client{Timeout: cfg.Timeout},
+		addressClient: channel.PublicClient(cfg.Timeout),
+		allowPrivate:  cfg.AllowPrivate,
+		secret:        []byte(cfg.Secret),
+		recipients:    cfg.Recipients,
+	}
+	if cfg.AllowPrivate {
+		s.addressClient = s.client
+	}
+
+	return s
+}
+
+func (s *Sender) Name() string {
+	return Name
+}
+
+func (s *Sender) Validate(msg channel.Message) error {
+	_, _, err := s.target(msg)
+	return err
+}
+
+func (s *Sender) Send(msg channel.Message) error {
+	target, client, err := s.target(msg)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(Payload{
+		ID:          msg.NotificationID,
+		RecipientID: msg.RecipientID,
+		Text:        msg.Text,
+		ScheduledAt: msg.Date.UTC(),
+		Metadata:    msg.Metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	header := http.Header{}
+	header.Set(HeaderTimestamp, timestamp)
+	header.Set(HeaderNotificationID, strconv.FormatInt(msg.NotificationID, 10))
+	if len(s.secret) > 0 {
+		header.Set(HeaderSignature, "sha256="+Sign(s.secret, timestamp, body))
+	}
+
+	if err = channel.PostJSON(client, target, body, header); err != nil {
+		return fmt.Errorf("webhook delivery failed: %w", err)
+	}
+
+	return nil
+}
+
+// Sign вычисляет подпись запроса: hex(HMAC-SHA256(secret, timestamp + "." + body)).
+// Получатель должен сравнить её с заголовком X-Webhook-Signature и отклонять устаревшие timestamp.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// target возвращает URL из уведомления или, если он не указан, URL получателя из конфигурации,
+// и клиент, которым на него можно отправлять.
+func (s *Sender) target(msg channel.Message) (string, *http.Client, error) {
+	if msg.Address == "" {
+		target := s.recipients[strconv.FormatInt(msg.RecipientID, 10)]
+		if target == "" {
+			return "", nil, fmt.Errorf("webhook URL is not set for recipient %d", msg.RecipientID)
+		}
+
+		if err := channel.ValidateURL(target); err != nil {
+			return "", nil, fmt.Errorf("invalid webhook URL: %w", err)
+		}
+
+		return target, s.client, nil
+	}
+
+	validate := channel.ValidatePublicURL
+	if s.allowPrivate {
+		validate = channel.ValidateURL
+	}
+
+	if err := validate(msg.Address); err != nil {
+		return "", nil, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+
+	return msg.Address, s.addressClient, nil
+}
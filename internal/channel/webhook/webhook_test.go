@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_Send(t *testing.T) {
+	var (
+		gotHeader http.Header
+		gotBody   []byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Clone()
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := New(config.Webhook{Secret: "secret", Timeout: time.Second, AllowPrivate: true})
+
+	date := time.Date(2025, 8, 9, 20, 55, 0, 0, time.UTC)
+	err := s.Send(channel.Message{
+		NotificationID: 42,
+		RecipientID:    7,
+		Address:        srv.URL,
+		Text:           "Deploy window opens",
+		Date:           date,
+		Metadata:       json.RawMessage(`{"env":"prod"}`),
+	})
+	require.NoError(t, err)
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(gotBody, &payload))
+	assert.Equal(t, int64(42), payload.ID)
+	assert.Equal(t, int64(7), payload.RecipientID)
+	assert.Equal(t, "Deploy window opens", payload.Text)
+	assert.True(t, date.Equal(payload.ScheduledAt))
+	assert.JSONEq(t, `{"env":"prod"}`, string(payload.Metadata))
+
+	timestamp := gotHeader.Get(HeaderTimestamp)
+	_, err = strconv.ParseInt(timestamp, 10, 64)
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/json", gotHeader.Get("Content-Type"))
+	assert.Equal(t, "42", gotHeader.Get(HeaderNotificationID))
+	assert.Equal(t, "sha256="+Sign([]byte("secret"), timestamp, gotBody), gotHeader.Get(HeaderSignature))
+}
+
+func TestSender_SendRecipientURL(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	s := New(config.Webhook{Timeout: time.Second, Recipients: map[string]string{"7": srv.URL}})
+
+	require.NoError(t, s.Send(channel.Message{NotificationID: 1, RecipientID: 7, Text: "Test"}))
+	assert.True(t, called)
+}
+
+func TestSender_SendNon2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	s := New(config.Webhook{Timeout: time.Second, AllowPrivate: true})
+
+	err := s.Send(channel.Message{NotificationID: 1, Address: srv.URL, Text: "Test"})
+	assert.ErrorContains(t, err, "status 503")
+}
+
+func TestSender_SendTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	s := New(config.Webhook{Timeout: 50 * time.Millisecond, AllowPrivate: true})
+
+	err := s.Send(channel.Message{NotificationID: 1, Address: srv.URL, Text: "Test"})
+	assert.Error(t, err)
+}
+
+func TestSender_Validate(t *testing.T) {
+	s := New(config.Webhook{Recipients: map[string]string{"7": "https://example.com/hook"}})
+
+	assert.NoError(t, s.Validate(channel.Message{Address: "https://example.com/hook"}))
+	assert.NoError(t, s.Validate(channel.Message{RecipientID: 7}))
+	assert.Error(t, s.Validate(channel.Message{RecipientID: 8}))
+	assert.Error(t, s.Validate(channel.Message{Address: "ftp://example.com"}))
+	assert.Error(t, s.Validate(channel.Message{Address: "not a url"}))
+}
+
+func TestSender_PrivateAddress(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	s := New(config.Webhook{Timeout: time.Second})
+
+	for _, address := range []string{
+		srv.URL,
+		"http://localhost/hook",
+		"http://10.0.0.5/hook",
+		"http://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://0.0.0.0/hook",
+	} {
+		assert.ErrorIs(t, s.Validate(channel.Message{Address: address}), channel.ErrPrivateAddress, address)
+	}
+
+	err := s.Send(channel.Message{NotificationID: 1, Address: srv.URL, Text: "Test"})
+	assert.ErrorIs(t, err, channel.ErrPrivateAddress)
+	assert.False(t, called)
+}
+
+func TestSign(t *testing.T) {
+	// Эталон: printf '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		Sign([]byte("secret"), "1700000000", []byte("{}")))
+}
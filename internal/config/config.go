@@ -8,12 +8,28 @@ import (
 )
 
 type Config struct {
-	Env        string     `yaml:"env" env-default:"local"`
-	Database   Database   `yaml:"database"`
-	HTTPServer HTTPServer `yaml:"http_server"`
-	Redis      Redis      `yaml:"redis"`
-	Rabbit     Rabbit     `yaml:"rabbit"`
-	TGToken    string     `yaml:"tg_token"`
+	Env         string      `yaml:"env" env-default:"local"`
+	Database    Database    `yaml:"database"`
+	HTTPServer  HTTPServer  `yaml:"http_server"`
+	Redis       Redis       `yaml:"redis"`
+	Rabbit      Rabbit      `yaml:"rabbit"`
+	Scheduler   Scheduler   `yaml:"scheduler"`
+	Outbox      Outbox      `yaml:"outbox"`
+	Reconciler  Reconciler  `yaml:"reconciler"`
+	Retry       Retry       `yaml:"retry"`
+	Email       Email       `yaml:"email"`
+	Webhook     Webhook     `yaml:"webhook"`
+	Slack       Slack       `yaml:"slack"`
+	Discord     Discord     `yaml:"discord"`
+	Attachments Attachments `yaml:"attachments"`
+	Telegram    Telegram    `yaml:"telegram"`
+	Escalation  Escalation  `yaml:"escalation"`
+	Recipients  Recipients  `yaml:"recipients"`
+	TGToken     string      `yaml:"tg_token"`
+
+	// Fallback — резервные каналы получателей по recipient_id для уведомлений,
+	// в которых список резервных каналов не указан явно.
+	Fallback map[string][]Route `yaml:"fallback"`
 }
 
 type Database struct {
@@ -42,12 +58,162 @@ type Redis struct {
 	PoolTimeout  time.Duration `yaml:"pool_timeout" env-default:"30s"`
 }
 
+// Rabbit — подключение к RabbitMQ и потребление очереди уведомлений.
+// Workers — сколько уведомлений отправляется параллельно, Prefetch — сколько неподтверждённых
+// сообщений RabbitMQ выдаёт потребителю заранее (basic.qos).
 type Rabbit struct {
-	Host      string `yaml:"host" env-default:"localhost"`
-	Port      int    `yaml:"port" env-default:"5672"`
-	User      string `yaml:"user" env-default:"guest"`
-	Password  string `yaml:"password" env-default:"guest"`
-	QueueName string `yaml:"queue_name" env-default:"notifications_queue"`
+	Host      string    `yaml:"host" env-default:"localhost"`
+	Port      int       `yaml:"port" env-default:"5672"`
+	User      string    `yaml:"user" env-default:"guest"`
+	Password  string    `yaml:"password" env-default:"guest"`
+	QueueName string    `yaml:"queue_name" env-default:"notifications_queue"`
+	Workers   int       `yaml:"workers" env-default:"8"`
+	Prefetch  int       `yaml:"prefetch" env-default:"32"`
+	Publisher Publisher `yaml:"publisher"`
+}
+
+// Publisher — пул каналов публикации в режиме подтверждений. Публикация считается успешной,
+// только когда RabbitMQ подтвердил сообщение; ConfirmTimeout ограничивает ожидание свободного канала и подтверждения.
+type Publisher struct {
+	Channels       int           `yaml:"channels" env-default:"4"`
+	ConfirmTimeout time.Duration `yaml:"confirm_timeout" env-default:"5s"`
+}
+
+const (
+	SchedulerRabbit   = "rabbit"
+	SchedulerPostgres = "postgres"
+)
+
+// Scheduler определяет, кто решает, какие уведомления пора отправлять:
+// очередь RabbitMQ с задержкой (rabbit) или опрос таблицы notifications (postgres).
+type Scheduler struct {
+	Mode         string        `yaml:"mode" env-default:"rabbit"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	ClaimTimeout time.Duration `yaml:"claim_timeout" env-default:"5m"`
+}
+
+// Outbox настраивает фоновую публикацию сообщений из таблицы outbox в RabbitMQ.
+type Outbox struct {
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	Lease        time.Duration `yaml:"lease" env-default:"30s"`
+	RetryBase    time.Duration `yaml:"retry_base" env-default:"1s"`
+	RetryMax     time.Duration `yaml:"retry_max" env-default:"1m"`
+}
+
+// Reconciler настраивает поиск и повторную публикацию потерянных уведомлений.
+type Reconciler struct {
+	Interval  time.Duration `yaml:"interval" env-default:"5m"`
+	Grace     time.Duration `yaml:"grace" env-default:"1m"`
+	BatchSize int           `yaml:"batch_size" env-default:"500"`
+}
+
+// Retry задаёт политику повторных отправок: задержка перед попыткой n равна
+// BaseDelay * 2^(n-1), но не больше MaxDelay, со случайным разбросом ±Jitter.
+type Retry struct {
+	MaxAttempts int           `yaml:"max_attempts" env-default:"5"`
+	BaseDelay   time.Duration `yaml:"base_delay" env-default:"10s"`
+	MaxDelay    time.Duration `yaml:"max_delay" env-default:"1h"`
+	Jitter      float64       `yaml:"jitter" env-default:"0.2"`
+}
+
+// Email настраивает канал доставки по электронной почте. Канал включается, если задан Host.
+type Email struct {
+	Host     string        `yaml:"host"`
+	Port     int           `yaml:"port" env-default:"587"`
+	Username string        `yaml:"username"`
+	Password string        `yaml:"password"`
+	From     string        `yaml:"from"`
+	Subject  string        `yaml:"subject" env-default:"Напоминание"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"10s"`
+}
+
+// Webhook настраивает канал доставки HTTP-запросом. URL берётся из поля address уведомления,
+// а если оно пустое — из Recipients по recipient_id.
+// Адрес из уведомления задаёт клиент API, поэтому запросы на loopback, частные и link-local адреса
+// по нему запрещены, пока не включён AllowPrivate. На URL из Recipients ограничение не действует.
+type Webhook struct {
+	Secret       string            `yaml:"secret"`
+	Timeout      time.Duration     `yaml:"timeout" env-default:"10s"`
+	Recipients   map[string]string `yaml:"recipients"`
+	AllowPrivate bool              `yaml:"allow_private" env-default:"false"`
+}
+
+// Slack настраивает канал доставки через incoming webhook Slack.
+// WebhookURL используется для уведомлений без address.
+type Slack struct {
+	WebhookURL   string        `yaml:"webhook_url"`
+	Timeout      time.Duration `yaml:"timeout" env-default:"10s"`
+	AllowPrivate bool          `yaml:"allow_private" env-default:"false"`
+}
+
+// Discord настраивает канал доставки через webhook Discord.
+// WebhookURL используется для уведомлений без address.
+type Discord struct {
+	WebhookURL   string        `yaml:"webhook_url"`
+	Username     string        `yaml:"username" env-default:"Delayed Notifier"`
+	Timeout      time.Duration `yaml:"timeout" env-default:"10s"`
+	AllowPrivate bool          `yaml:"allow_private" env-default:"false"`
+}
+
+// Attachments настраивает хранение вложений: каталог локального хранилища, предельный размер файла
+// и срок хранения, после которого файлы, не нужные ожидающим уведомлениям и активным сериям, удаляются.
+type Attachments struct {
+	Dir             string        `yaml:"dir" env-default:"./data/attachments"`
+	MaxSize         int64         `yaml:"max_size" env-default:"20971520"`
+	Retention       time.Duration `yaml:"retention" env-default:"720h"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env-default:"1h"`
+}
+
+const (
+	UpdatesPolling = "polling"
+	UpdatesWebhook = "webhook"
+	UpdatesOff     = "off"
+)
+
+// Telegram настраивает приём обновлений бота: long polling (polling), webhook или off.
+// Пока обновления принимаются, под доставленными уведомлениями есть кнопки «Done» и «Snooze» на каждый
+// интервал из Snooze. В режиме webhook Telegram присылает обновления на WebhookURL с заголовком
+// WebhookSecret, а HTTP-сервер обслуживает путь из WebhookURL; без WebhookSecret сервис не запустится.
+type Telegram struct {
+	Updates       string          `yaml:"updates" env-default:"polling"`
+	PollTimeout   int             `yaml:"poll_timeout" env-default:"60"`
+	WebhookURL    string          `yaml:"webhook_url"`
+	WebhookSecret string          `yaml:"webhook_secret"`
+	Snooze        []time.Duration `yaml:"snooze" env-default:"10m,1h"`
+	RateLimit     RateLimit       `yaml:"rate_limit"`
+}
+
+// RateLimit — ограничения частоты отправки уведомлений в Telegram, общие для всех реплик через Redis.
+// Global — сообщений в секунду на бота, PerChat — в секунду в один чат, PerGroup — в минуту в одну группу.
+// Сообщение ждёт свободного места в корзинах до MaxWait, после чего отправка откладывается без расхода попытки.
+type RateLimit struct {
+	Enabled  bool          `yaml:"enabled" env-default:"true"`
+	Global   int           `yaml:"global" env-default:"30"`
+	PerChat  int           `yaml:"per_chat" env-default:"1"`
+	PerGroup int           `yaml:"per_group" env-default:"20"`
+	MaxWait  time.Duration `yaml:"max_wait" env-default:"10s"`
+}
+
+// Escalation настраивает фоновое выполнение шагов эскалации неподтверждённых уведомлений.
+// Lease — на сколько откладывается забранный шаг, чтобы после падения процесса его выполнили снова.
+type Escalation struct {
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"30s"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	Lease        time.Duration `yaml:"lease" env-default:"5m"`
+}
+
+// Recipients настраивает реестр получателей.
+// TokenTTL — сколько действует ссылка, по которой пользователь привязывает свой чат в Telegram.
+type Recipients struct {
+	TokenTTL time.Duration `yaml:"token_ttl" env-default:"168h"`
+}
+
+// Route — канал доставки и адрес получателя в нём.
+type Route struct {
+	Channel string `yaml:"channel"`
+	Address string `yaml:"address"`
 }
 
 func MustLoad() *Config {
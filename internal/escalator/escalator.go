@@ -0,0 +1,62 @@
+package escalator
+
+import (
+	"DelayedNotifier/internal/service"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Escalator периодически выполняет шаги эскалации уведомлений, которые не подтвердили вовремя.
+type Escalator struct {
+	service  *service.Service
+	log      *slog.Logger
+	interval time.Duration
+}
+
+func New(service *service.Service, log *slog.Logger, interval time.Duration) *Escalator {
+	return &Escalator{
+		service:  service,
+		log:      log,
+		interval: interval,
+	}
+}
+
+func (e *Escalator) Start(ctx context.Context) {
+	e.log.Info("Starting escalator", slog.String("interval", e.interval.String()))
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.log.Info("Escalator stopped")
+			return
+		case <-ticker.C:
+			e.escalate()
+		}
+	}
+}
+
+func (e *Escalator) escalate() {
+	notifications, err := e.service.ClaimDueEscalations()
+	if err != nil {
+		e.log.Error("Failed to claim due escalations", "error", err)
+		return
+	}
+
+	for i := range notifications {
+		notification := &notifications[i]
+
+		if err = e.service.EscalateNotification(notification); err != nil {
+			e.log.Error("Failed to escalate notification", "error", err, "notification_id", notification.ID)
+			continue
+		}
+
+		e.log.Info("Notification escalated",
+			slog.Int64("notification_id", notification.ID),
+			slog.Int("step", notification.EscalationLevel+1),
+		)
+	}
+}
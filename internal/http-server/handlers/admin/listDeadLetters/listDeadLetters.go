@@ -0,0 +1,76 @@
+package listDeadLetters
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/rabbitMQ/broker"
+	"errors"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type Response struct {
+	response.Response
+	DeadLetters []models.DeadLetter `json:"dead_letters"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=DeadLetterLister
+type DeadLetterLister interface {
+	ListDeadLetters(limit int) ([]models.DeadLetter, error)
+}
+
+func New(log *slog.Logger, dlq DeadLetterLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.listDeadLetters.New"
+
+		log = log.With(
+			slog.String("op", op),
+		)
+
+		limit := defaultLimit
+		if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
+			parsed, err := strconv.Atoi(rawLimit)
+			if err != nil || parsed <= 0 || parsed > maxLimit {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("invalid limit"))
+				return
+			}
+			limit = parsed
+		}
+
+		letters, err := dlq.ListDeadLetters(limit)
+		if errors.Is(err, broker.ErrUnavailable) {
+			log.Error("failed to list dead letters", sl.Err(err))
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, response.Error("message broker is unavailable, try again later"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to list dead letters", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to list dead letters"))
+
+			return
+		}
+
+		log.Info("dead letters listed", slog.Int("count", len(letters)))
+
+		responseOK(w, r, letters)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, letters []models.DeadLetter) {
+	render.JSON(w, r, Response{
+		Response:    response.OK(),
+		DeadLetters: letters,
+	})
+}
@@ -0,0 +1,77 @@
+package listDeadLetters
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/admin/listDeadLetters/mocks"
+	"DelayedNotifier/internal/models"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ListDeadLetters_Success(t *testing.T) {
+	mockDLQ := new(mocks.DeadLetterLister)
+	mockDLQ.On("ListDeadLetters", 10).Return([]models.DeadLetter{
+		{MessageID: "abc", NotificationID: 1, Body: "1", Reason: "retries exhausted"},
+	}, nil)
+
+	h := New(slog.Default(), mockDLQ)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/dlq?limit=10", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.DeadLetters, 1)
+	assert.Equal(t, "abc", resp.DeadLetters[0].MessageID)
+
+	mockDLQ.AssertExpectations(t)
+}
+
+func TestHandler_ListDeadLetters_DefaultLimit(t *testing.T) {
+	mockDLQ := new(mocks.DeadLetterLister)
+	mockDLQ.On("ListDeadLetters", defaultLimit).Return([]models.DeadLetter{}, nil)
+
+	h := New(slog.Default(), mockDLQ)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/dlq", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockDLQ.AssertExpectations(t)
+}
+
+func TestHandler_ListDeadLetters_InvalidLimit(t *testing.T) {
+	mockDLQ := new(mocks.DeadLetterLister)
+	h := New(slog.Default(), mockDLQ)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/dlq?limit=abc", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockDLQ.AssertNotCalled(t, "ListDeadLetters")
+}
+
+func TestHandler_ListDeadLetters_InternalError(t *testing.T) {
+	mockDLQ := new(mocks.DeadLetterLister)
+	mockDLQ.On("ListDeadLetters", defaultLimit).Return(nil, errors.New("channel closed"))
+
+	h := New(slog.Default(), mockDLQ)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/dlq", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	mockDLQ.AssertExpectations(t)
+}
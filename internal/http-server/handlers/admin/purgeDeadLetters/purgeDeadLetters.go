@@ -0,0 +1,54 @@
+package purgeDeadLetters
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/rabbitMQ/broker"
+	"errors"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Response struct {
+	response.Response
+	Purged int `json:"purged"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=DeadLettersPurger
+type DeadLettersPurger interface {
+	PurgeDeadLetters() (int, error)
+}
+
+func New(log *slog.Logger, dlq DeadLettersPurger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.purgeDeadLetters.New"
+
+		log = log.With(
+			slog.String("op", op),
+		)
+
+		purged, err := dlq.PurgeDeadLetters()
+		if errors.Is(err, broker.ErrUnavailable) {
+			log.Error("failed to purge dead letters", sl.Err(err))
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, response.Error("message broker is unavailable, try again later"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to purge dead letters", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to purge dead letters"))
+
+			return
+		}
+
+		log.Info("dead letters purged", slog.Int("purged", purged))
+
+		render.JSON(w, r, Response{
+			Response: response.OK(),
+			Purged:   purged,
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package purgeDeadLetters
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/admin/purgeDeadLetters/mocks"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_PurgeDeadLetters_Success(t *testing.T) {
+	mockDLQ := new(mocks.DeadLettersPurger)
+	mockDLQ.On("PurgeDeadLetters").Return(5, nil)
+
+	h := New(slog.Default(), mockDLQ)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/dlq", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, resp.Purged)
+
+	mockDLQ.AssertExpectations(t)
+}
+
+func TestHandler_PurgeDeadLetters_InternalError(t *testing.T) {
+	mockDLQ := new(mocks.DeadLettersPurger)
+	mockDLQ.On("PurgeDeadLetters").Return(0, errors.New("channel closed"))
+
+	h := New(slog.Default(), mockDLQ)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/dlq", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	mockDLQ.AssertExpectations(t)
+}
@@ -0,0 +1,49 @@
+package reconcileReport
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/models"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Response struct {
+	response.Response
+	Report *models.ReconcileReport `json:"report"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=ReconcileReporter
+type ReconcileReporter interface {
+	LastReport() *models.ReconcileReport
+}
+
+func New(log *slog.Logger, reconciler ReconcileReporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.reconcileReport.New"
+
+		log = log.With(
+			slog.String("op", op),
+		)
+
+		report := reconciler.LastReport()
+		if report == nil {
+			log.Info("reconcile report not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("reconciler has not run yet"))
+
+			return
+		}
+
+		log.Info("reconcile report received")
+
+		responseOK(w, r, report)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, report *models.ReconcileReport) {
+	render.JSON(w, r, Response{
+		Response: response.OK(),
+		Report:   report,
+	})
+}
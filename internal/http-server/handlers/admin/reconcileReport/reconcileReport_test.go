@@ -0,0 +1,52 @@
+package reconcileReport
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/admin/reconcileReport/mocks"
+	"DelayedNotifier/internal/models"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ReconcileReport_Success(t *testing.T) {
+	mockReconciler := new(mocks.ReconcileReporter)
+	mockReconciler.On("LastReport").Return(&models.ReconcileReport{
+		Scanned:   2,
+		Recovered: []int64{1, 2},
+		Failed:    []int64{},
+	})
+
+	h := New(slog.Default(), mockReconciler)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/reconciler", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.Report.Scanned)
+	assert.Equal(t, []int64{1, 2}, resp.Report.Recovered)
+
+	mockReconciler.AssertExpectations(t)
+}
+
+func TestHandler_ReconcileReport_NotRunYet(t *testing.T) {
+	mockReconciler := new(mocks.ReconcileReporter)
+	mockReconciler.On("LastReport").Return(nil)
+
+	h := New(slog.Default(), mockReconciler)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/reconciler", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockReconciler.AssertExpectations(t)
+}
@@ -0,0 +1,68 @@
+package replayDeadLetter
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/rabbitMQ/broker"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=DeadLetterReplayer
+type DeadLetterReplayer interface {
+	ReplayDeadLetter(messageID string) error
+}
+
+func New(log *slog.Logger, dlq DeadLetterReplayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.replayDeadLetter.New"
+
+		messageID := chi.URLParam(r, "id")
+		if messageID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("message id is required"))
+			return
+		}
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("message_id", messageID),
+		)
+
+		err := dlq.ReplayDeadLetter(messageID)
+		if errors.Is(err, broker.ErrDeadLetterNotFound) {
+			log.Info("dead letter not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("dead letter not found"))
+
+			return
+		}
+		if errors.Is(err, broker.ErrUnavailable) {
+			log.Error("failed to replay dead letter", sl.Err(err))
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, response.Error("message broker is unavailable, try again later"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to replay dead letter", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to replay dead letter"))
+
+			return
+		}
+
+		log.Info("dead letter replayed")
+
+		render.JSON(w, r, Response{
+			Response: response.OK(),
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package replayDeadLetter
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/admin/replayDeadLetter/mocks"
+	"DelayedNotifier/internal/rabbitMQ/broker"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRequest(id string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/admin/dlq/"+id+"/replay", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_ReplayDeadLetter_Success(t *testing.T) {
+	mockDLQ := new(mocks.DeadLetterReplayer)
+	mockDLQ.On("ReplayDeadLetter", "abc").Return(nil)
+
+	h := New(slog.Default(), mockDLQ)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("abc"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockDLQ.AssertExpectations(t)
+}
+
+func TestHandler_ReplayDeadLetter_MissingID(t *testing.T) {
+	mockDLQ := new(mocks.DeadLetterReplayer)
+	h := New(slog.Default(), mockDLQ)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/dlq//replay", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockDLQ.AssertNotCalled(t, "ReplayDeadLetter")
+}
+
+func TestHandler_ReplayDeadLetter_NotFound(t *testing.T) {
+	mockDLQ := new(mocks.DeadLetterReplayer)
+	mockDLQ.On("ReplayDeadLetter", "missing").Return(broker.ErrDeadLetterNotFound)
+
+	h := New(slog.Default(), mockDLQ)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("missing"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockDLQ.AssertExpectations(t)
+}
+
+func TestHandler_ReplayDeadLetter_InternalError(t *testing.T) {
+	mockDLQ := new(mocks.DeadLetterReplayer)
+	mockDLQ.On("ReplayDeadLetter", "abc").Return(errors.New("channel closed"))
+
+	h := New(slog.Default(), mockDLQ)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("abc"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	mockDLQ.AssertExpectations(t)
+}
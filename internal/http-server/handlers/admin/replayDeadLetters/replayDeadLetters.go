@@ -0,0 +1,48 @@
+package replayDeadLetters
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Response struct {
+	response.Response
+	Replayed int `json:"replayed"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=DeadLettersReplayer
+type DeadLettersReplayer interface {
+	ReplayDeadLetters() (int, error)
+}
+
+func New(log *slog.Logger, dlq DeadLettersReplayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.replayDeadLetters.New"
+
+		log = log.With(
+			slog.String("op", op),
+		)
+
+		replayed, err := dlq.ReplayDeadLetters()
+		if err != nil {
+			log.Error("failed to replay dead letters", sl.Err(err), slog.Int("replayed", replayed))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, Response{
+				Response: response.Error("failed to replay dead letters"),
+				Replayed: replayed,
+			})
+
+			return
+		}
+
+		log.Info("dead letters replayed", slog.Int("replayed", replayed))
+
+		render.JSON(w, r, Response{
+			Response: response.OK(),
+			Replayed: replayed,
+		})
+	}
+}
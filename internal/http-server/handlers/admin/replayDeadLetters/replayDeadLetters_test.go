@@ -0,0 +1,53 @@
+package replayDeadLetters
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/admin/replayDeadLetters/mocks"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ReplayDeadLetters_Success(t *testing.T) {
+	mockDLQ := new(mocks.DeadLettersReplayer)
+	mockDLQ.On("ReplayDeadLetters").Return(3, nil)
+
+	h := New(slog.Default(), mockDLQ)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/dlq/replay", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, resp.Replayed)
+
+	mockDLQ.AssertExpectations(t)
+}
+
+func TestHandler_ReplayDeadLetters_PartialFailure(t *testing.T) {
+	mockDLQ := new(mocks.DeadLettersReplayer)
+	mockDLQ.On("ReplayDeadLetters").Return(1, errors.New("channel closed"))
+
+	h := New(slog.Default(), mockDLQ)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/dlq/replay", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Replayed)
+
+	mockDLQ.AssertExpectations(t)
+}
@@ -0,0 +1,52 @@
+package runReconcile
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/models"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Response struct {
+	response.Response
+	Report *models.ReconcileReport `json:"report"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=ReconcileRunner
+type ReconcileRunner interface {
+	Run() *models.ReconcileReport
+}
+
+func New(log *slog.Logger, reconciler ReconcileRunner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.runReconcile.New"
+
+		log = log.With(
+			slog.String("op", op),
+		)
+
+		report := reconciler.Run()
+		if report.Error != "" {
+			log.Error("reconcile failed", slog.String("error", report.Error))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, Response{
+				Response: response.Error("reconcile failed"),
+				Report:   report,
+			})
+
+			return
+		}
+
+		log.Info("reconcile completed", slog.Int("recovered", len(report.Recovered)))
+
+		responseOK(w, r, report)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, report *models.ReconcileReport) {
+	render.JSON(w, r, Response{
+		Response: response.OK(),
+		Report:   report,
+	})
+}
@@ -0,0 +1,55 @@
+package runReconcile
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/admin/runReconcile/mocks"
+	"DelayedNotifier/internal/models"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_RunReconcile_Success(t *testing.T) {
+	mockReconciler := new(mocks.ReconcileRunner)
+	mockReconciler.On("Run").Return(&models.ReconcileReport{
+		Scanned:   1,
+		Recovered: []int64{42},
+		Failed:    []int64{},
+	})
+
+	h := New(slog.Default(), mockReconciler)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/reconciler/run", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{42}, resp.Report.Recovered)
+
+	mockReconciler.AssertExpectations(t)
+}
+
+func TestHandler_RunReconcile_Error(t *testing.T) {
+	mockReconciler := new(mocks.ReconcileRunner)
+	mockReconciler.On("Run").Return(&models.ReconcileReport{
+		Recovered: []int64{},
+		Failed:    []int64{},
+		Error:     "database error",
+	})
+
+	h := New(slog.Default(), mockReconciler)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/reconciler/run", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	mockReconciler.AssertExpectations(t)
+}
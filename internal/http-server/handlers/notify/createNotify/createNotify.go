@@ -2,32 +2,115 @@ package createNotify
 
 import (
 	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/datetime"
 	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
 	"DelayedNotifier/internal/storage"
+	"encoding/json"
 	"errors"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"io"
 	"log/slog"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"time"
 )
 
 type Request struct {
-	RecipientID int64  `json:"recipient_id" validate:"required"`
-	Date        string `json:"date" validate:"required"`
-	Text        string `json:"text" validate:"required"`
+	RecipientID int64 `json:"recipient_id,omitempty" validate:"required_without_all=Address Recipient"`
+	// Recipient — alias или external_id получателя из реестра /recipients вместо recipient_id.
+	Recipient string `json:"recipient,omitempty" validate:"excluded_with=RecipientID"`
+	// Channel — канал доставки из channel.Registry: "telegram" (по умолчанию), "email" (если настроен SMTP),
+	// "webhook", "slack" или "discord".
+	Channel string `json:"channel,omitempty"`
+	// Address — адрес получателя для каналов, отличных от Telegram (e-mail, URL вебхука Slack, Discord и т.п.).
+	Address string `json:"address,omitempty"`
+	// Fallback — резервные каналы, которые пробуются по порядку, когда попытки основного исчерпаны.
+	Fallback []Route `json:"fallback,omitempty" validate:"omitempty,dive"`
+	// Metadata — JSON-объект, который передаётся получателю вебхука без изменений.
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	// Date — дата в формате RFC 3339 со смещением или "2006-01-02 15:04:05" в часовом поясе Timezone.
+	Date string `json:"date,omitempty" validate:"required_without_all=Delay When Schedule"`
+	// Delay — задержка относительно текущего момента: "90m", "1h30m", "2d".
+	Delay string `json:"delay,omitempty"`
+	// When — фраза на английском или русском языке: "tomorrow at 9", "через 15 минут".
+	When string `json:"when,omitempty"`
+	// Timezone — часовой пояс IANA (например, "Europe/Moscow"), по умолчанию UTC.
+	Timezone string `json:"timezone,omitempty"`
+	Text     string `json:"text" validate:"required"`
+	// ParseMode — разметка текста для Telegram: "plain" (по умолчанию), "MarkdownV2" или "HTML".
+	ParseMode             string `json:"parse_mode,omitempty"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview,omitempty"`
+	DisableNotification   bool   `json:"disable_notification,omitempty"`
+	// Attachment — файл по ссылке; загруженный файл передаётся в поле file multipart-запроса.
+	Attachment *Attachment `json:"attachment,omitempty"`
+	// Escalation — шаги эскалации, если получатель не нажал «Done» под сообщением.
+	Escalation []EscalationStep `json:"escalation,omitempty" validate:"omitempty,dive"`
+	Schedule   *Schedule        `json:"schedule,omitempty"`
+}
+
+// Attachment описывает вложение: вид ("photo" или "document", по умолчанию — по типу файла),
+// ссылку на файл и имя, под которым его увидит получатель.
+type Attachment struct {
+	Kind     string `json:"kind,omitempty" validate:"omitempty,oneof=photo document"`
+	URL      string `json:"url,omitempty" validate:"omitempty,url"`
+	FileName string `json:"file_name,omitempty"`
+}
+
+// Schedule задаёт повторение уведомления: cron-выражение или правило RRULE,
+// а также необязательные дату окончания серии и число срабатываний.
+type Schedule struct {
+	Cron  string `json:"cron,omitempty" validate:"required_without=RRule,excluded_with=RRule"`
+	RRule string `json:"rrule,omitempty" validate:"required_without=Cron"`
+	Until string `json:"until,omitempty"`
+	Count int    `json:"count,omitempty" validate:"gte=0"`
+}
+
+// EscalationStep — шаг эскалации: через After ("15m", "1h") без подтверждения уведомление
+// отправляется получателям Recipients, а если список пуст — повторно исходному получателю.
+type EscalationStep struct {
+	After      string                `json:"after" validate:"required"`
+	Recipients []EscalationRecipient `json:"recipients,omitempty" validate:"omitempty,dive"`
+}
+
+// EscalationRecipient — получатель шага эскалации: recipient_id для Telegram или адрес в канале Channel.
+type EscalationRecipient struct {
+	RecipientID int64  `json:"recipient_id,omitempty" validate:"required_without=Address"`
+	Channel     string `json:"channel,omitempty"`
+	Address     string `json:"address,omitempty"`
+}
+
+// Route — резервный канал доставки и адрес получателя в нём.
+type Route struct {
+	Channel string `json:"channel" validate:"required"`
+	Address string `json:"address,omitempty"`
 }
 
 type Response struct {
 	response.Response
-	NotificationID int64 `json:"notification_id"`
+	NotificationID int64  `json:"notification_id"`
+	SeriesID       *int64 `json:"series_id,omitempty"`
+	AttachmentID   *int64 `json:"attachment_id,omitempty"`
+	Channel        string `json:"channel"`
+	DateUTC        string `json:"date_utc"`
+	DateLocal      string `json:"date_local"`
+	Timezone       string `json:"timezone"`
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=CreateNotification
 type CreateNotification interface {
-	CreateNotification(recipientID int64, dateStr, text string) (int64, error)
+	CreateNotification(params models.NewNotification) (*models.Notification, error)
 }
 
-func New(log *slog.Logger, notify CreateNotification) http.HandlerFunc {
+// New принимает уведомление и сохраняет его. Недоступность RabbitMQ здесь не ошибка: уведомление
+// и запись outbox сохраняются в PostgreSQL одной транзакцией, и relay опубликует сообщение после
+// переподключения брокера, поэтому отдельного ответа об отказе брокера нет.
+// maxAttachmentSize ограничивает тело multipart-запроса: без ограничения файл любого размера
+// был бы записан во временный файл ещё до проверки размера вложения.
+func New(log *slog.Logger, notify CreateNotification, maxAttachmentSize int64) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.notify.createNotify.New"
 
@@ -35,9 +118,31 @@ func New(log *slog.Logger, notify CreateNotification) http.HandlerFunc {
 			slog.String("op", op),
 		)
 
-		var req Request
+		var (
+			req    Request
+			upload *multipart.FileHeader
+			err    error
+		)
 
-		err := render.DecodeJSON(r.Body, &req)
+		if isMultipart(r) {
+			r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentSize+multipartOverhead)
+			upload, err = decodeMultipart(r, &req)
+			if r.MultipartForm != nil {
+				defer func() {
+					_ = r.MultipartForm.RemoveAll()
+				}()
+			}
+		} else {
+			err = render.DecodeJSON(r.Body, &req)
+		}
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			log.Info("request body too large", slog.Int64("limit", tooLarge.Limit))
+			render.Status(r, http.StatusRequestEntityTooLarge)
+			render.JSON(w, r, response.Error("attachment is too large"))
+
+			return
+		}
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
 			render.Status(r, http.StatusBadRequest)
@@ -59,14 +164,39 @@ func New(log *slog.Logger, notify CreateNotification) http.HandlerFunc {
 			return
 		}
 
-		notifyId, err := notify.CreateNotification(req.RecipientID, req.Date, req.Text)
+		params := toParams(req)
+
+		if upload != nil {
+			file, err := upload.Open()
+			if err != nil {
+				log.Error("failed to open uploaded file", sl.Err(err))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("failed to read attachment"))
+
+				return
+			}
+			defer func() {
+				_ = file.Close()
+			}()
+
+			params.Attachment = uploadParams(params.Attachment, upload, file)
+		}
+
+		notification, err := notify.CreateNotification(params)
 		if errors.Is(err, storage.ErrNotifyExists) {
-			log.Info("notify already exists", slog.Int64("notification_id", notifyId))
+			log.Info("notify already exists")
 			render.Status(r, http.StatusConflict)
 			render.JSON(w, r, response.Error("notify already exists"))
 
 			return
 		}
+		if errors.Is(err, service.ErrInvalidInput) {
+			log.Info("invalid notify", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error(err.Error()))
+
+			return
+		}
 		if err != nil {
 			log.Error("failed to add notify", sl.Err(err))
 			render.Status(r, http.StatusInternalServerError)
@@ -75,15 +205,121 @@ func New(log *slog.Logger, notify CreateNotification) http.HandlerFunc {
 			return
 		}
 
-		log.Info("notify added", slog.Int64("notification_id", notifyId))
+		log.Info("notify added", slog.Int64("notification_id", notification.ID))
 
-		responseOK(w, r, notifyId)
+		responseOK(w, r, notification)
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request, notificationId int64) {
+func toParams(req Request) models.NewNotification {
+	params := models.NewNotification{
+		RecipientID: req.RecipientID,
+		Recipient:   req.Recipient,
+		Channel:     req.Channel,
+		Address:     req.Address,
+		Date:        req.Date,
+		Delay:       req.Delay,
+		When:        req.When,
+		Timezone:    req.Timezone,
+		Text:        req.Text,
+		Options: models.MessageOptions{
+			ParseMode:             req.ParseMode,
+			DisableWebPagePreview: req.DisableWebPagePreview,
+			DisableNotification:   req.DisableNotification,
+		},
+		Metadata: req.Metadata,
+	}
+
+	if req.Attachment != nil {
+		params.Attachment = &models.NewAttachment{
+			Kind:     req.Attachment.Kind,
+			URL:      req.Attachment.URL,
+			FileName: req.Attachment.FileName,
+		}
+	}
+
+	for _, route := range req.Fallback {
+		params.Fallback = append(params.Fallback, models.Route{Channel: route.Channel, Address: route.Address})
+	}
+
+	for _, step := range req.Escalation {
+		escalation := models.EscalationStep{After: step.After}
+		for _, recipient := range step.Recipients {
+			escalation.Recipients = append(escalation.Recipients, models.EscalationTarget{
+				RecipientID: recipient.RecipientID,
+				Channel:     recipient.Channel,
+				Address:     recipient.Address,
+			})
+		}
+		params.Escalation = append(params.Escalation, escalation)
+	}
+
+	if req.Schedule != nil {
+		params.Schedule = &models.ScheduleParams{
+			Cron:  req.Schedule.Cron,
+			RRule: req.Schedule.RRule,
+			Until: req.Schedule.Until,
+			Count: req.Schedule.Count,
+		}
+	}
+
+	return params
+}
+
+const (
+	// maxMemory — часть multipart-запроса, которая держится в памяти; остальное пишется во временные файлы.
+	maxMemory = 1 << 20
+	// multipartOverhead — запас сверх размера вложения на поле request и заголовки частей.
+	multipartOverhead = 1 << 20
+)
+
+func isMultipart(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "multipart/form-data"
+}
+
+// decodeMultipart разбирает multipart/form-data: JSON запроса в поле request и файл в поле file.
+func decodeMultipart(r *http.Request, req *Request) (*multipart.FileHeader, error) {
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(r.FormValue("request")), req); err != nil {
+		return nil, err
+	}
+
+	_, header, err := r.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, nil
+	}
+
+	return header, err
+}
+
+// uploadParams дополняет вложение из JSON загруженным файлом.
+func uploadParams(attachment *models.NewAttachment, upload *multipart.FileHeader, file io.Reader) *models.NewAttachment {
+	if attachment == nil {
+		attachment = &models.NewAttachment{}
+	}
+
+	if attachment.FileName == "" {
+		attachment.FileName = upload.Filename
+	}
+	attachment.ContentType = upload.Header.Get("Content-Type")
+	attachment.Content = file
+
+	return attachment
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, notification *models.Notification) {
 	render.JSON(w, r, Response{
 		Response:       response.OK(),
-		NotificationID: notificationId,
+		NotificationID: notification.ID,
+		SeriesID:       notification.SeriesID,
+		AttachmentID:   notification.AttachmentID,
+		Channel:        notification.Channel,
+		DateUTC:        notification.Date.UTC().Format(time.RFC3339),
+		DateLocal:      datetime.In(notification.Date, notification.Timezone).Format(time.RFC3339),
+		Timezone:       notification.Timezone,
 	})
 }
@@ -2,29 +2,37 @@ package createNotify
 
 import (
 	"DelayedNotifier/internal/http-server/handlers/notify/createNotify/mocks"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
 	"DelayedNotifier/internal/storage"
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// maxAttachmentSize — ограничение размера вложения в тестах.
+const maxAttachmentSize = 1 << 10
+
 func TestHandler_CreateNotify_Success(t *testing.T) {
 	mockStorage := new(mocks.CreateNotification)
 	mockStorage.On(
 		"CreateNotification",
-		mock.AnythingOfType("int64"),
-		mock.AnythingOfType("string"),
-		mock.AnythingOfType("string"),
-	).Return(int64(1), nil)
+		mock.AnythingOfType("models.NewNotification"),
+	).Return(&models.Notification{ID: 1}, nil)
 
-	h := New(slog.Default(), mockStorage)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
 
 	reqBody := `{"recipient_id": 123, "date": "2024-01-01", "text": "Test"}`
 	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
@@ -43,9 +51,424 @@ func TestHandler_CreateNotify_Success(t *testing.T) {
 	mockStorage.AssertExpectations(t)
 }
 
+func TestHandler_CreateNotify_Timezone(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		models.NewNotification{
+			RecipientID: 123,
+			Date:        "2025-08-09 23:55:00",
+			Timezone:    "Europe/Moscow",
+			Text:        "Test",
+		},
+	).Return(&models.Notification{
+		ID:       1,
+		Date:     time.Date(2025, 8, 9, 20, 55, 0, 0, time.UTC),
+		Timezone: "Europe/Moscow",
+	}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "timezone": "Europe/Moscow", "text": "Test"}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-08-09T20:55:00Z", resp.DateUTC)
+	assert.Equal(t, "2025-08-09T23:55:00+03:00", resp.DateLocal)
+	assert.Equal(t, "Europe/Moscow", resp.Timezone)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_RecipientAlias(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		models.NewNotification{
+			Recipient: "alice",
+			Delay:     "1h",
+			Text:      "Test",
+		},
+	).Return(&models.Notification{ID: 1}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient": "alice", "delay": "1h", "text": "Test"}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_RecipientAndRecipientID(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient": "alice", "recipient_id": 123, "delay": "1h", "text": "Test"}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockStorage.AssertNotCalled(t, "CreateNotification", mock.Anything)
+}
+
+func TestHandler_CreateNotify_When(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		models.NewNotification{
+			RecipientID: 123,
+			When:        "завтра в 9",
+			Timezone:    "Europe/Moscow",
+			Text:        "Test",
+		},
+	).Return(&models.Notification{
+		ID:       1,
+		Date:     time.Date(2025, 8, 9, 6, 0, 0, 0, time.UTC),
+		Timezone: "Europe/Moscow",
+	}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "when": "завтра в 9", "timezone": "Europe/Moscow", "text": "Test"}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-08-09T09:00:00+03:00", resp.DateLocal)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_MissingDate(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "text": "Test"}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	mockStorage.AssertNotCalled(t, "CreateNotification")
+}
+
+func TestHandler_CreateNotify_EmailChannel(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		models.NewNotification{
+			Channel: "email",
+			Address: "user@example.com",
+			Date:    "2025-08-09 23:55:00",
+			Text:    "Test",
+		},
+	).Return(&models.Notification{
+		ID:       1,
+		Channel:  "email",
+		Address:  "user@example.com",
+		Date:     time.Date(2025, 8, 9, 23, 55, 0, 0, time.UTC),
+		Timezone: "UTC",
+	}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"channel": "email", "address": "user@example.com", "date": "2025-08-09 23:55:00", "text": "Test"}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "email", resp.Channel)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_Fallback(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		models.NewNotification{
+			RecipientID: 123,
+			Date:        "2025-08-09 23:55:00",
+			Text:        "Test",
+			Fallback: []models.Route{
+				{Channel: "email", Address: "user@example.com"},
+				{Channel: "webhook", Address: "https://example.com/hook"},
+			},
+		},
+	).Return(&models.Notification{ID: 1, Channel: "telegram"}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "text": "Test", "fallback": [
+		{"channel": "email", "address": "user@example.com"},
+		{"channel": "webhook", "address": "https://example.com/hook"}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_MessageOptions(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		models.NewNotification{
+			RecipientID: 123,
+			Date:        "2025-08-09 23:55:00",
+			Text:        "*Test*",
+			Options: models.MessageOptions{
+				ParseMode:             "MarkdownV2",
+				DisableWebPagePreview: true,
+				DisableNotification:   true,
+			},
+		},
+	).Return(&models.Notification{ID: 1, Channel: "telegram"}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "text": "*Test*",
+		"parse_mode": "MarkdownV2", "disable_web_page_preview": true, "disable_notification": true}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_Escalation(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		models.NewNotification{
+			RecipientID: 123,
+			Date:        "2025-08-09 23:55:00",
+			Text:        "Сервер недоступен",
+			Escalation: []models.EscalationStep{
+				{After: "10m"},
+				{After: "15m", Recipients: []models.EscalationTarget{
+					{RecipientID: 456},
+					{Channel: "email", Address: "oncall@example.com"},
+				}},
+			},
+		},
+	).Return(&models.Notification{ID: 1, Channel: "telegram"}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "text": "Сервер недоступен",
+		"escalation": [
+			{"after": "10m"},
+			{"after": "15m", "recipients": [{"recipient_id": 456}, {"channel": "email", "address": "oncall@example.com"}]}
+		]}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_EscalationValidationError(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "text": "Сервер недоступен",
+		"escalation": [{"recipients": [{"channel": "email"}]}]}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockStorage.AssertNotCalled(t, "CreateNotification", mock.Anything)
+}
+
+func TestHandler_CreateNotify_AttachmentURL(t *testing.T) {
+	attachmentID := int64(7)
+
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		models.NewNotification{
+			RecipientID: 123,
+			Date:        "2025-08-09 23:55:00",
+			Text:        "График продаж",
+			Attachment: &models.NewAttachment{
+				Kind: models.AttachmentPhoto,
+				URL:  "https://example.com/chart.png",
+			},
+		},
+	).Return(&models.Notification{ID: 1, Channel: "telegram", AttachmentID: &attachmentID}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "text": "График продаж",
+		"attachment": {"kind": "photo", "url": "https://example.com/chart.png"}}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, &attachmentID, resp.AttachmentID)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_AttachmentUpload(t *testing.T) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	assert.NoError(t, form.WriteField("request", `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "text": "Отчёт"}`))
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="report.pdf"`)
+	header.Set("Content-Type", "application/pdf")
+	part, err := form.CreatePart(header)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte("%PDF-1.4"))
+	assert.NoError(t, err)
+	assert.NoError(t, form.Close())
+
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		mock.MatchedBy(func(params models.NewNotification) bool {
+			if params.Text != "Отчёт" || params.Attachment == nil || params.Attachment.Content == nil {
+				return false
+			}
+			content, err := io.ReadAll(params.Attachment.Content)
+			return err == nil &&
+				string(content) == "%PDF-1.4" &&
+				params.Attachment.FileName == "report.pdf" &&
+				params.Attachment.ContentType == "application/pdf"
+		}),
+	).Return(&models.Notification{ID: 1, Channel: "telegram"}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	req := httptest.NewRequest(http.MethodPost, "/notify", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_AttachmentTooLarge(t *testing.T) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	assert.NoError(t, form.WriteField("request", `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "text": "Отчёт"}`))
+
+	part, err := form.CreateFormFile("file", "report.pdf")
+	assert.NoError(t, err)
+	_, err = part.Write(bytes.Repeat([]byte("x"), maxAttachmentSize+multipartOverhead+1))
+	assert.NoError(t, err)
+	assert.NoError(t, form.Close())
+
+	mockStorage := new(mocks.CreateNotification)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	req := httptest.NewRequest(http.MethodPost, "/notify", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+
+	mockStorage.AssertNotCalled(t, "CreateNotification", mock.Anything)
+}
+
+func TestHandler_CreateNotify_AttachmentValidationError(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "text": "Test",
+		"attachment": {"kind": "video", "url": "https://example.com/clip.mp4"}}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockStorage.AssertNotCalled(t, "CreateNotification")
+}
+
+func TestHandler_CreateNotify_FallbackValidationError(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "date": "2025-08-09 23:55:00", "text": "Test", "fallback": [{"address": "user@example.com"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	mockStorage.AssertNotCalled(t, "CreateNotification")
+}
+
 func TestHandler_CreateNotify_ValidationError(t *testing.T) {
 	mockStorage := new(mocks.CreateNotification)
-	h := New(slog.Default(), mockStorage)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
 
 	reqBody := `{"date": "2024-01-01", "text": "Test"}`
 	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
@@ -63,12 +486,10 @@ func TestHandler_CreateNotify_Conflict(t *testing.T) {
 	mockStorage := new(mocks.CreateNotification)
 	mockStorage.On(
 		"CreateNotification",
-		mock.AnythingOfType("int64"),
-		mock.AnythingOfType("string"),
-		mock.AnythingOfType("string"),
-	).Return(int64(0), storage.ErrNotifyExists)
+		mock.AnythingOfType("models.NewNotification"),
+	).Return(nil, storage.ErrNotifyExists)
 
-	h := New(slog.Default(), mockStorage)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
 
 	reqBody := `{"recipient_id": 123, "date": "2024-01-01", "text": "Test"}`
 	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
@@ -85,12 +506,10 @@ func TestHandler_CreateNotify_InternalError(t *testing.T) {
 	mockStorage := new(mocks.CreateNotification)
 	mockStorage.On(
 		"CreateNotification",
-		mock.AnythingOfType("int64"),
-		mock.AnythingOfType("string"),
-		mock.AnythingOfType("string"),
-	).Return(int64(0), errors.New("some internal error"))
+		mock.AnythingOfType("models.NewNotification"),
+	).Return(nil, errors.New("some internal error"))
 
-	h := New(slog.Default(), mockStorage)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
 
 	reqBody := `{"recipient_id": 123, "date": "2024-01-01", "text": "Test"}`
 	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
@@ -102,3 +521,80 @@ func TestHandler_CreateNotify_InternalError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 	mockStorage.AssertExpectations(t)
 }
+
+func TestHandler_CreateNotify_Schedule(t *testing.T) {
+	seriesID := int64(7)
+
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		models.NewNotification{
+			RecipientID: 123,
+			Text:        "Standup",
+			Schedule: &models.ScheduleParams{
+				Cron:  "0 9 * * 1-5",
+				Count: 10,
+			},
+		},
+	).Return(&models.Notification{ID: 1, SeriesID: &seriesID}, nil)
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "text": "Standup", "schedule": {"cron": "0 9 * * 1-5", "count": 10}}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.NotificationID)
+	assert.Equal(t, &seriesID, resp.SeriesID)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_CreateNotify_ScheduleValidationError(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	for _, reqBody := range []string{
+		`{"recipient_id": 123, "text": "Test"}`,
+		`{"recipient_id": 123, "text": "Test", "schedule": {}}`,
+		`{"recipient_id": 123, "text": "Test", "schedule": {"cron": "* * * * *", "rrule": "FREQ=DAILY"}}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, reqBody)
+	}
+
+	mockStorage.AssertNotCalled(t, "CreateNotification")
+}
+
+func TestHandler_CreateNotify_InvalidInput(t *testing.T) {
+	mockStorage := new(mocks.CreateNotification)
+	mockStorage.On(
+		"CreateNotification",
+		mock.AnythingOfType("models.NewNotification"),
+	).Return(nil, fmt.Errorf("%w: invalid cron expression", service.ErrInvalidInput))
+
+	h := New(slog.Default(), mockStorage, maxAttachmentSize)
+
+	reqBody := `{"recipient_id": 123, "text": "Test", "schedule": {"cron": "bogus"}}`
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockStorage.AssertExpectations(t)
+}
@@ -2,34 +2,40 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	models "DelayedNotifier/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // CreateNotification is an autogenerated mock type for the CreateNotification type
 type CreateNotification struct {
 	mock.Mock
 }
 
-// CreateNotification provides a mock function with given fields: recipientID, dateStr, text
-func (_m *CreateNotification) CreateNotification(recipientID int64, dateStr string, text string) (int64, error) {
-	ret := _m.Called(recipientID, dateStr, text)
+// CreateNotification provides a mock function with given fields: params
+func (_m *CreateNotification) CreateNotification(params models.NewNotification) (*models.Notification, error) {
+	ret := _m.Called(params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateNotification")
 	}
 
-	var r0 int64
+	var r0 *models.Notification
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64, string, string) (int64, error)); ok {
-		return rf(recipientID, dateStr, text)
+	if rf, ok := ret.Get(0).(func(models.NewNotification) (*models.Notification, error)); ok {
+		return rf(params)
 	}
-	if rf, ok := ret.Get(0).(func(int64, string, string) int64); ok {
-		r0 = rf(recipientID, dateStr, text)
+	if rf, ok := ret.Get(0).(func(models.NewNotification) *models.Notification); ok {
+		r0 = rf(params)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Notification)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64, string, string) error); ok {
-		r1 = rf(recipientID, dateStr, text)
+	if rf, ok := ret.Get(1).(func(models.NewNotification) error); ok {
+		r1 = rf(params)
 	} else {
 		r1 = ret.Error(1)
 	}
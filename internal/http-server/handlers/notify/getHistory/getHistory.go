@@ -0,0 +1,69 @@
+package getHistory
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/storage"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Response struct {
+	response.Response
+	Events []models.NotificationEvent `json:"events"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=GetNotificationEvents
+type GetNotificationEvents interface {
+	GetNotificationEvents(notificationID int64) ([]models.NotificationEvent, error)
+}
+
+func New(log *slog.Logger, notify GetNotificationEvents) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.notify.getHistory.New"
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("invalid notifyID"))
+			return
+		}
+
+		log = log.With(
+			slog.String("op", op),
+			slog.Int64("notification_id", id),
+		)
+
+		events, err := notify.GetNotificationEvents(id)
+		if errors.Is(err, storage.ErrNotifyNotFound) {
+			log.Info("notify not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("notify not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get notify history", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to get notify history"))
+
+			return
+		}
+
+		log.Info("notify history received", slog.Int("events", len(events)))
+
+		responseOK(w, r, events)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, events []models.NotificationEvent) {
+	render.JSON(w, r, Response{
+		Response: response.OK(),
+		Events:   events,
+	})
+}
@@ -0,0 +1,87 @@
+package getHistory
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/notify/getHistory/mocks"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRequest(id string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/notify/"+id+"/history", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_GetHistory_Success(t *testing.T) {
+	mockStorage := new(mocks.GetNotificationEvents)
+	mockStorage.On("GetNotificationEvents", int64(1)).Return([]models.NotificationEvent{
+		{ID: 1, NotificationID: 1, Event: models.EventCreated, Status: models.StatusPending, Channel: "telegram"},
+		{ID: 2, NotificationID: 1, Event: models.EventFallback, Status: models.StatusPending, Channel: "email", Attempt: 5, Error: "bot was blocked"},
+		{ID: 3, NotificationID: 1, Event: models.EventSent, Status: models.StatusSent, Channel: "email", Attempt: 1},
+	}, nil)
+
+	h := New(slog.Default(), mockStorage)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("1"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Events, 3)
+	assert.Equal(t, models.EventSent, resp.Events[2].Event)
+	assert.Equal(t, "email", resp.Events[2].Channel)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_GetHistory_InvalidID(t *testing.T) {
+	mockStorage := new(mocks.GetNotificationEvents)
+	h := New(slog.Default(), mockStorage)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("abc"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockStorage.AssertNotCalled(t, "GetNotificationEvents")
+}
+
+func TestHandler_GetHistory_NotFound(t *testing.T) {
+	mockStorage := new(mocks.GetNotificationEvents)
+	mockStorage.On("GetNotificationEvents", int64(999)).Return(nil, storage.ErrNotifyNotFound)
+
+	h := New(slog.Default(), mockStorage)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("999"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_GetHistory_InternalError(t *testing.T) {
+	mockStorage := new(mocks.GetNotificationEvents)
+	mockStorage.On("GetNotificationEvents", int64(1)).Return(nil, errors.New("database error"))
+
+	h := New(slog.Default(), mockStorage)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("1"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	mockStorage.AssertExpectations(t)
+}
@@ -2,7 +2,9 @@ package getStatus
 
 import (
 	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/datetime"
 	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/models"
 	"DelayedNotifier/internal/storage"
 	"errors"
 	"github.com/go-chi/chi/v5"
@@ -10,19 +12,40 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type Response struct {
 	response.Response
-	Status string `json:"status"`
+	Status    string `json:"status"`
+	Channel   string `json:"channel"`
+	DateUTC   string `json:"date_utc"`
+	DateLocal string `json:"date_local"`
+	Timezone  string `json:"timezone"`
+	// MessageIDs — идентификаторы отправленных сообщений; длинный текст в Telegram уходит несколькими сообщениями.
+	MessageIDs []int64 `json:"message_ids,omitempty"`
+	// AcknowledgedAt — когда получатель нажал «Done» или «Snooze» под сообщением.
+	AcknowledgedAt string `json:"acknowledged_at,omitempty"`
+	// Escalation — состояние эскалации, если у уведомления есть политика эскалации.
+	Escalation *Escalation `json:"escalation,omitempty"`
 }
 
-//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=GetNotificationStatus
-type GetNotificationStatus interface {
-	GetNotificationStatus(notificationID int64) (string, error)
+// Escalation описывает ход эскалации: состояние (waiting, active, acknowledged, exhausted),
+// число выполненных шагов из общего числа, срок следующего шага и уведомления, созданные шагами.
+type Escalation struct {
+	State         string  `json:"state"`
+	Step          int     `json:"step"`
+	Steps         int     `json:"steps"`
+	NextAt        string  `json:"next_at,omitempty"`
+	Notifications []int64 `json:"notifications,omitempty"`
 }
 
-func New(log *slog.Logger, notify GetNotificationStatus) http.HandlerFunc {
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=GetNotification
+type GetNotification interface {
+	GetNotificationByID(notificationID int64) (*models.Notification, error)
+}
+
+func New(log *slog.Logger, notify GetNotification) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.notify.getStatus.New"
 
@@ -45,7 +68,7 @@ func New(log *slog.Logger, notify GetNotificationStatus) http.HandlerFunc {
 			slog.Int64("notification_id", id),
 		)
 
-		status, err := notify.GetNotificationStatus(id)
+		notification, err := notify.GetNotificationByID(id)
 		if errors.Is(err, storage.ErrNotifyNotFound) {
 			log.Info("notify not found", slog.Int64("notification_id", id))
 			render.Status(r, http.StatusNotFound)
@@ -63,13 +86,38 @@ func New(log *slog.Logger, notify GetNotificationStatus) http.HandlerFunc {
 
 		log.Info("notify status received", slog.Int64("notification_id", id))
 
-		responseOK(w, r, status)
+		responseOK(w, r, notification)
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request, status string) {
+func responseOK(w http.ResponseWriter, r *http.Request, notification *models.Notification) {
+	var acknowledgedAt string
+	if notification.AcknowledgedAt != nil {
+		acknowledgedAt = notification.AcknowledgedAt.UTC().Format(time.RFC3339)
+	}
+
+	var escalation *Escalation
+	if len(notification.Escalation) > 0 {
+		escalation = &Escalation{
+			State:         notification.EscalationState(),
+			Step:          notification.EscalationLevel,
+			Steps:         len(notification.Escalation),
+			Notifications: notification.Escalations,
+		}
+		if notification.EscalateAt != nil {
+			escalation.NextAt = notification.EscalateAt.UTC().Format(time.RFC3339)
+		}
+	}
+
 	render.JSON(w, r, Response{
-		Response: response.OK(),
-		Status:   status,
+		Response:       response.OK(),
+		Status:         notification.Status,
+		Channel:        notification.Route().Channel,
+		DateUTC:        notification.Date.UTC().Format(time.RFC3339),
+		DateLocal:      datetime.In(notification.Date, notification.Timezone).Format(time.RFC3339),
+		Timezone:       notification.Timezone,
+		MessageIDs:     notification.MessageIDs,
+		AcknowledgedAt: acknowledgedAt,
+		Escalation:     escalation,
 	})
 }
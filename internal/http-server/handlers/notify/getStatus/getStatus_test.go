@@ -2,6 +2,7 @@ package getStatus
 
 import (
 	"DelayedNotifier/internal/http-server/handlers/notify/getStatus/mocks"
+	"DelayedNotifier/internal/models"
 	"DelayedNotifier/internal/storage"
 	"context"
 	"encoding/json"
@@ -10,14 +11,20 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestHandler_GetStatus_Success(t *testing.T) {
-	mockStorage := new(mocks.GetNotificationStatus)
-	mockStorage.On("GetNotificationStatus", int64(1)).Return("delivered", nil)
+	mockStorage := new(mocks.GetNotification)
+	mockStorage.On("GetNotificationByID", int64(1)).Return(&models.Notification{
+		ID:       1,
+		Status:   "delivered",
+		Date:     time.Date(2025, 8, 9, 20, 55, 0, 0, time.UTC),
+		Timezone: "Europe/Moscow",
+	}, nil)
 
 	h := New(slog.Default(), mockStorage)
 
@@ -35,12 +42,128 @@ func TestHandler_GetStatus_Success(t *testing.T) {
 	err := json.NewDecoder(rr.Body).Decode(&resp)
 	assert.NoError(t, err)
 	assert.Equal(t, "delivered", resp.Status)
+	assert.Equal(t, "2025-08-09T20:55:00Z", resp.DateUTC)
+	assert.Equal(t, "2025-08-09T23:55:00+03:00", resp.DateLocal)
+	assert.Equal(t, "Europe/Moscow", resp.Timezone)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_GetStatus_Partial(t *testing.T) {
+	mockStorage := new(mocks.GetNotification)
+	mockStorage.On("GetNotificationByID", int64(1)).Return(&models.Notification{
+		ID:         1,
+		Channel:    "telegram",
+		Status:     models.StatusPartial,
+		Date:       time.Date(2025, 8, 9, 20, 55, 0, 0, time.UTC),
+		Timezone:   "UTC",
+		SentParts:  2,
+		MessageIDs: []int64{101, 102},
+	}, nil)
+
+	h := New(slog.Default(), mockStorage)
+
+	req := httptest.NewRequest(http.MethodGet, "/status/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusPartial, resp.Status)
+	assert.Equal(t, []int64{101, 102}, resp.MessageIDs)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_GetStatus_Acknowledged(t *testing.T) {
+	acknowledgedAt := time.Date(2025, 8, 9, 21, 5, 0, 0, time.UTC)
+
+	mockStorage := new(mocks.GetNotification)
+	mockStorage.On("GetNotificationByID", int64(1)).Return(&models.Notification{
+		ID:             1,
+		Channel:        "telegram",
+		Status:         models.StatusAcknowledged,
+		Date:           time.Date(2025, 8, 9, 20, 55, 0, 0, time.UTC),
+		Timezone:       "UTC",
+		MessageIDs:     []int64{101},
+		AcknowledgedAt: &acknowledgedAt,
+	}, nil)
+
+	h := New(slog.Default(), mockStorage)
+
+	req := httptest.NewRequest(http.MethodGet, "/status/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusAcknowledged, resp.Status)
+	assert.Equal(t, "2025-08-09T21:05:00Z", resp.AcknowledgedAt)
+
+	mockStorage.AssertExpectations(t)
+}
+
+func TestHandler_GetStatus_Escalation(t *testing.T) {
+	escalateAt := time.Date(2025, 8, 9, 21, 25, 0, 0, time.UTC)
+
+	mockStorage := new(mocks.GetNotification)
+	mockStorage.On("GetNotificationByID", int64(1)).Return(&models.Notification{
+		ID:       1,
+		Channel:  "telegram",
+		Status:   models.StatusSent,
+		Date:     time.Date(2025, 8, 9, 20, 55, 0, 0, time.UTC),
+		Timezone: "UTC",
+		Escalation: []models.EscalationStep{
+			{After: "15m"},
+			{After: "15m", Recipients: []models.EscalationTarget{{RecipientID: 456, Channel: "telegram"}}},
+		},
+		EscalationLevel: 1,
+		EscalateAt:      &escalateAt,
+		Escalations:     []int64{2},
+	}, nil)
+
+	h := New(slog.Default(), mockStorage)
+
+	req := httptest.NewRequest(http.MethodGet, "/status/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, &Escalation{
+		State:         models.EscalationActive,
+		Step:          1,
+		Steps:         2,
+		NextAt:        "2025-08-09T21:25:00Z",
+		Notifications: []int64{2},
+	}, resp.Escalation)
 
 	mockStorage.AssertExpectations(t)
 }
 
 func TestHandler_GetStatus_InvalidID(t *testing.T) {
-	mockStorage := new(mocks.GetNotificationStatus)
+	mockStorage := new(mocks.GetNotification)
 	h := New(slog.Default(), mockStorage)
 
 	req := httptest.NewRequest(http.MethodGet, "/status/abc", nil)
@@ -52,11 +175,11 @@ func TestHandler_GetStatus_InvalidID(t *testing.T) {
 	h.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
-	mockStorage.AssertNotCalled(t, "GetNotificationStatus")
+	mockStorage.AssertNotCalled(t, "GetNotificationByID")
 }
 
 func TestHandler_GetStatus_MissingID(t *testing.T) {
-	mockStorage := new(mocks.GetNotificationStatus)
+	mockStorage := new(mocks.GetNotification)
 	h := New(slog.Default(), mockStorage)
 
 	req := httptest.NewRequest(http.MethodGet, "/status/", nil)
@@ -64,12 +187,12 @@ func TestHandler_GetStatus_MissingID(t *testing.T) {
 	h.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
-	mockStorage.AssertNotCalled(t, "GetNotificationStatus")
+	mockStorage.AssertNotCalled(t, "GetNotificationByID")
 }
 
 func TestHandler_GetStatus_NotFound(t *testing.T) {
-	mockStorage := new(mocks.GetNotificationStatus)
-	mockStorage.On("GetNotificationStatus", int64(999)).Return("", storage.ErrNotifyNotFound)
+	mockStorage := new(mocks.GetNotification)
+	mockStorage.On("GetNotificationByID", int64(999)).Return(nil, storage.ErrNotifyNotFound)
 
 	h := New(slog.Default(), mockStorage)
 
@@ -85,8 +208,8 @@ func TestHandler_GetStatus_NotFound(t *testing.T) {
 	mockStorage.AssertExpectations(t)
 }
 func TestHandler_GetStatus_InternalError(t *testing.T) {
-	mockStorage := new(mocks.GetNotificationStatus)
-	mockStorage.On("GetNotificationStatus", int64(1)).Return("", errors.New("database error"))
+	mockStorage := new(mocks.GetNotification)
+	mockStorage.On("GetNotificationByID", int64(1)).Return(nil, errors.New("database error"))
 
 	h := New(slog.Default(), mockStorage)
 
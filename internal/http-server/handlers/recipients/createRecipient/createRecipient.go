@@ -0,0 +1,116 @@
+package createRecipient
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
+	"DelayedNotifier/internal/storage"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+)
+
+// Request регистрирует получателя: alias и/или external_id, по которым его адресуют вызывающие сервисы.
+// ChatID указывается, если чат уже известен; иначе в ответе приходит ссылка для привязки чата.
+type Request struct {
+	Alias      string `json:"alias,omitempty" validate:"required_without=ExternalID"`
+	ExternalID string `json:"external_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	ChatID     *int64 `json:"chat_id,omitempty"`
+}
+
+type Response struct {
+	response.Response
+	Recipient *models.Recipient `json:"recipient,omitempty"`
+	// OnboardingURL — ссылка t.me, открыв которую пользователь привяжет свой чат к получателю.
+	OnboardingURL string `json:"onboarding_url,omitempty"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=RecipientCreator
+type RecipientCreator interface {
+	CreateRecipient(params models.NewRecipient) (*models.Recipient, error)
+}
+
+// New возвращает обработчик POST /recipients; botName — имя бота для ссылки привязки чата.
+func New(log *slog.Logger, recipients RecipientCreator, botName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.recipients.createRecipient.New"
+
+		log = log.With(
+			slog.String("op", op),
+		)
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("failed to decode request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+
+			return
+		}
+
+		recipient, err := recipients.CreateRecipient(models.NewRecipient{
+			Alias:      req.Alias,
+			ExternalID: req.ExternalID,
+			Name:       req.Name,
+			ChatID:     req.ChatID,
+		})
+		if errors.Is(err, storage.ErrRecipientExists) {
+			log.Info("recipient already exists")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.Error("recipient with this alias or external_id already exists"))
+
+			return
+		}
+		if errors.Is(err, service.ErrInvalidInput) {
+			log.Info("invalid recipient", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to create recipient", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to create recipient"))
+
+			return
+		}
+
+		log.Info("recipient created", slog.Int64("recipient_id", recipient.ID))
+
+		render.Status(r, http.StatusCreated)
+		responseOK(w, r, recipient, botName)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, recipient *models.Recipient, botName string) {
+	render.JSON(w, r, Response{
+		Response:      response.OK(),
+		Recipient:     recipient,
+		OnboardingURL: onboardingURL(botName, recipient.OnboardingToken),
+	})
+}
+
+// onboardingURL — ссылка, по которой Telegram откроет бота и отправит /start с токеном.
+func onboardingURL(botName, token string) string {
+	if botName == "" || token == "" {
+		return ""
+	}
+
+	return "https://t.me/" + botName + "?start=" + token
+}
@@ -0,0 +1,124 @@
+package createRecipient
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/recipients/createRecipient/mocks"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
+	"DelayedNotifier/internal/storage"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/recipients", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestHandler_CreateRecipient_Success(t *testing.T) {
+	mockRecipients := new(mocks.RecipientCreator)
+	mockRecipients.On("CreateRecipient", models.NewRecipient{
+		Alias:      "alice",
+		ExternalID: "user-42",
+		Name:       "Alice",
+	}).Return(&models.Recipient{ID: 1, Alias: "alice", ExternalID: "user-42", Active: true, OnboardingToken: "token"}, nil)
+
+	h := New(slog.Default(), mockRecipients, "reminder_bot")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest(`{"alias": "alice", "external_id": "user-42", "name": "Alice"}`))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Recipient.ID)
+	assert.Equal(t, "https://t.me/reminder_bot?start=token", resp.OnboardingURL)
+
+	mockRecipients.AssertExpectations(t)
+}
+
+func TestHandler_CreateRecipient_WithChat(t *testing.T) {
+	chatID := int64(123)
+
+	mockRecipients := new(mocks.RecipientCreator)
+	mockRecipients.On("CreateRecipient", models.NewRecipient{Alias: "bob", ChatID: &chatID}).
+		Return(&models.Recipient{ID: 2, Alias: "bob", ChatID: &chatID, Active: true}, nil)
+
+	h := New(slog.Default(), mockRecipients, "reminder_bot")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest(`{"alias": "bob", "chat_id": 123}`))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.OnboardingURL)
+
+	mockRecipients.AssertExpectations(t)
+}
+
+func TestHandler_CreateRecipient_ValidationError(t *testing.T) {
+	mockRecipients := new(mocks.RecipientCreator)
+	h := New(slog.Default(), mockRecipients, "reminder_bot")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest(`{"name": "Nobody"}`))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockRecipients.AssertNotCalled(t, "CreateRecipient", mock.Anything)
+}
+
+func TestHandler_CreateRecipient_InvalidInput(t *testing.T) {
+	mockRecipients := new(mocks.RecipientCreator)
+	mockRecipients.On("CreateRecipient", mock.AnythingOfType("models.NewRecipient")).
+		Return(nil, fmt.Errorf("%w: alias must start with a letter", service.ErrInvalidInput))
+
+	h := New(slog.Default(), mockRecipients, "reminder_bot")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest(`{"alias": "42"}`))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockRecipients.AssertExpectations(t)
+}
+
+func TestHandler_CreateRecipient_Exists(t *testing.T) {
+	mockRecipients := new(mocks.RecipientCreator)
+	mockRecipients.On("CreateRecipient", mock.AnythingOfType("models.NewRecipient")).
+		Return(nil, storage.ErrRecipientExists)
+
+	h := New(slog.Default(), mockRecipients, "reminder_bot")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest(`{"alias": "alice"}`))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockRecipients.AssertExpectations(t)
+}
+
+func TestHandler_CreateRecipient_InternalError(t *testing.T) {
+	mockRecipients := new(mocks.RecipientCreator)
+	mockRecipients.On("CreateRecipient", mock.AnythingOfType("models.NewRecipient")).
+		Return(nil, errors.New("database error"))
+
+	h := New(slog.Default(), mockRecipients, "reminder_bot")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest(`{"alias": "alice"}`))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	mockRecipients.AssertExpectations(t)
+}
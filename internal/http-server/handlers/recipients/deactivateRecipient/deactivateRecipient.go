@@ -0,0 +1,73 @@
+package deactivateRecipient
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/storage"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=RecipientDeactivator
+type RecipientDeactivator interface {
+	DeactivateRecipient(recipientID int64) error
+}
+
+func New(log *slog.Logger, recipients RecipientDeactivator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.recipients.deactivateRecipient.New"
+
+		recipientID := chi.URLParam(r, "id")
+		if recipientID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{"error": "recipientID is required"})
+			return
+		}
+
+		id, err := strconv.ParseInt(recipientID, 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{"error": "invalid recipientID"})
+			return
+		}
+
+		log = log.With(
+			slog.String("op", op),
+			slog.Int64("recipient_id", id),
+		)
+
+		err = recipients.DeactivateRecipient(id)
+		if errors.Is(err, storage.ErrRecipientNotFound) {
+			log.Info("recipient not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("recipient not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to deactivate recipient", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to deactivate recipient"))
+
+			return
+		}
+
+		log.Info("recipient deactivated")
+
+		responseOK(w, r)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, Response{
+		Response: response.OK(),
+	})
+}
@@ -0,0 +1,72 @@
+package deactivateRecipient
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/recipients/deactivateRecipient/mocks"
+	"DelayedNotifier/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRequest(id string) *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/recipients/"+id, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_DeactivateRecipient_Success(t *testing.T) {
+	mockRecipients := new(mocks.RecipientDeactivator)
+	mockRecipients.On("DeactivateRecipient", int64(1)).Return(nil)
+
+	h := New(slog.Default(), mockRecipients)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("1"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockRecipients.AssertExpectations(t)
+}
+
+func TestHandler_DeactivateRecipient_InvalidID(t *testing.T) {
+	mockRecipients := new(mocks.RecipientDeactivator)
+	h := New(slog.Default(), mockRecipients)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("abc"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockRecipients.AssertNotCalled(t, "DeactivateRecipient")
+}
+
+func TestHandler_DeactivateRecipient_NotFound(t *testing.T) {
+	mockRecipients := new(mocks.RecipientDeactivator)
+	mockRecipients.On("DeactivateRecipient", int64(999)).Return(storage.ErrRecipientNotFound)
+
+	h := New(slog.Default(), mockRecipients)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("999"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockRecipients.AssertExpectations(t)
+}
+
+func TestHandler_DeactivateRecipient_InternalError(t *testing.T) {
+	mockRecipients := new(mocks.RecipientDeactivator)
+	mockRecipients.On("DeactivateRecipient", int64(1)).Return(errors.New("database error"))
+
+	h := New(slog.Default(), mockRecipients)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("1"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	mockRecipients.AssertExpectations(t)
+}
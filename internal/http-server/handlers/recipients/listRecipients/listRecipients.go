@@ -0,0 +1,63 @@
+package listRecipients
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/models"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Response struct {
+	response.Response
+	Recipients []models.Recipient `json:"recipients"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=RecipientLister
+type RecipientLister interface {
+	ListRecipients(includeInactive bool) ([]models.Recipient, error)
+}
+
+// New возвращает обработчик GET /recipients; деактивированные получатели показываются с ?all=true.
+func New(log *slog.Logger, recipients RecipientLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.recipients.listRecipients.New"
+
+		log = log.With(
+			slog.String("op", op),
+		)
+
+		var includeInactive bool
+		if rawAll := r.URL.Query().Get("all"); rawAll != "" {
+			parsed, err := strconv.ParseBool(rawAll)
+			if err != nil {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("invalid all"))
+				return
+			}
+			includeInactive = parsed
+		}
+
+		list, err := recipients.ListRecipients(includeInactive)
+		if err != nil {
+			log.Error("failed to list recipients", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to list recipients"))
+
+			return
+		}
+
+		log.Info("recipients listed", slog.Int("count", len(list)))
+
+		responseOK(w, r, list)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, recipients []models.Recipient) {
+	render.JSON(w, r, Response{
+		Response:   response.OK(),
+		Recipients: recipients,
+	})
+}
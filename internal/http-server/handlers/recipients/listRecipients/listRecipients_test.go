@@ -0,0 +1,78 @@
+package listRecipients
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/recipients/listRecipients/mocks"
+	"DelayedNotifier/internal/models"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ListRecipients_Success(t *testing.T) {
+	mockRecipients := new(mocks.RecipientLister)
+	mockRecipients.On("ListRecipients", false).Return([]models.Recipient{
+		{ID: 1, Alias: "alice", Active: true},
+	}, nil)
+
+	h := New(slog.Default(), mockRecipients)
+
+	req := httptest.NewRequest(http.MethodGet, "/recipients", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Recipients, 1)
+	assert.Equal(t, "alice", resp.Recipients[0].Alias)
+
+	mockRecipients.AssertExpectations(t)
+}
+
+func TestHandler_ListRecipients_All(t *testing.T) {
+	mockRecipients := new(mocks.RecipientLister)
+	mockRecipients.On("ListRecipients", true).Return([]models.Recipient{}, nil)
+
+	h := New(slog.Default(), mockRecipients)
+
+	req := httptest.NewRequest(http.MethodGet, "/recipients?all=true", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockRecipients.AssertExpectations(t)
+}
+
+func TestHandler_ListRecipients_InvalidAll(t *testing.T) {
+	mockRecipients := new(mocks.RecipientLister)
+	h := New(slog.Default(), mockRecipients)
+
+	req := httptest.NewRequest(http.MethodGet, "/recipients?all=maybe", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockRecipients.AssertNotCalled(t, "ListRecipients", mock.Anything)
+}
+
+func TestHandler_ListRecipients_InternalError(t *testing.T) {
+	mockRecipients := new(mocks.RecipientLister)
+	mockRecipients.On("ListRecipients", false).Return(nil, errors.New("database error"))
+
+	h := New(slog.Default(), mockRecipients)
+
+	req := httptest.NewRequest(http.MethodGet, "/recipients", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	mockRecipients.AssertExpectations(t)
+}
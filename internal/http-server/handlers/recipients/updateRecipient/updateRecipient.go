@@ -0,0 +1,131 @@
+package updateRecipient
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
+	"DelayedNotifier/internal/storage"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request меняет только переданные поля; пустая строка очищает alias или external_id.
+// RotateToken выдаёт новую ссылку для привязки чата, например если пользователь сменил аккаунт.
+type Request struct {
+	Alias       *string `json:"alias,omitempty"`
+	ExternalID  *string `json:"external_id,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	ChatID      *int64  `json:"chat_id,omitempty"`
+	Active      *bool   `json:"active,omitempty"`
+	RotateToken bool    `json:"rotate_token,omitempty"`
+}
+
+type Response struct {
+	response.Response
+	Recipient *models.Recipient `json:"recipient,omitempty"`
+	// OnboardingURL — ссылка t.me для привязки чата, если у получателя есть действующий токен.
+	OnboardingURL string `json:"onboarding_url,omitempty"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=RecipientUpdater
+type RecipientUpdater interface {
+	UpdateRecipient(recipientID int64, update models.RecipientUpdate) (*models.Recipient, error)
+}
+
+// New возвращает обработчик PATCH /recipients/{id}; botName — имя бота для ссылки привязки чата.
+func New(log *slog.Logger, recipients RecipientUpdater, botName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.recipients.updateRecipient.New"
+
+		recipientID := chi.URLParam(r, "id")
+		if recipientID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{"error": "recipientID is required"})
+			return
+		}
+
+		id, err := strconv.ParseInt(recipientID, 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{"error": "invalid recipientID"})
+			return
+		}
+
+		log = log.With(
+			slog.String("op", op),
+			slog.Int64("recipient_id", id),
+		)
+
+		var req Request
+		if err = render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("failed to decode request"))
+
+			return
+		}
+
+		recipient, err := recipients.UpdateRecipient(id, models.RecipientUpdate{
+			Alias:       req.Alias,
+			ExternalID:  req.ExternalID,
+			Name:        req.Name,
+			ChatID:      req.ChatID,
+			Active:      req.Active,
+			RotateToken: req.RotateToken,
+		})
+		if errors.Is(err, storage.ErrRecipientNotFound) {
+			log.Info("recipient not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("recipient not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrRecipientExists) {
+			log.Info("recipient alias or external id is taken")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.Error("recipient with this alias or external_id already exists"))
+
+			return
+		}
+		if errors.Is(err, service.ErrInvalidInput) {
+			log.Info("invalid recipient", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to update recipient", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to update recipient"))
+
+			return
+		}
+
+		log.Info("recipient updated")
+
+		responseOK(w, r, recipient, botName)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, recipient *models.Recipient, botName string) {
+	render.JSON(w, r, Response{
+		Response:      response.OK(),
+		Recipient:     recipient,
+		OnboardingURL: onboardingURL(botName, recipient.OnboardingToken),
+	})
+}
+
+// onboardingURL — ссылка, по которой Telegram откроет бота и отправит /start с токеном.
+func onboardingURL(botName, token string) string {
+	if botName == "" || token == "" {
+		return ""
+	}
+
+	return "https://t.me/" + botName + "?start=" + token
+}
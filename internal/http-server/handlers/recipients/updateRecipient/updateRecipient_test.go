@@ -0,0 +1,91 @@
+package updateRecipient
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/recipients/updateRecipient/mocks"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
+	"DelayedNotifier/internal/storage"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRequest(id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/recipients/"+id, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_UpdateRecipient_Success(t *testing.T) {
+	alias := "alice"
+
+	mockRecipients := new(mocks.RecipientUpdater)
+	mockRecipients.On("UpdateRecipient", int64(1), models.RecipientUpdate{Alias: &alias, RotateToken: true}).
+		Return(&models.Recipient{ID: 1, Alias: alias, Active: true, OnboardingToken: "fresh"}, nil)
+
+	h := New(slog.Default(), mockRecipients, "reminder_bot")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("1", `{"alias": "alice", "rotate_token": true}`))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", resp.Recipient.Alias)
+	assert.Equal(t, "https://t.me/reminder_bot?start=fresh", resp.OnboardingURL)
+
+	mockRecipients.AssertExpectations(t)
+}
+
+func TestHandler_UpdateRecipient_InvalidID(t *testing.T) {
+	mockRecipients := new(mocks.RecipientUpdater)
+	h := New(slog.Default(), mockRecipients, "reminder_bot")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("abc", `{}`))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockRecipients.AssertNotCalled(t, "UpdateRecipient", mock.Anything, mock.Anything)
+}
+
+func TestHandler_UpdateRecipient_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"not found", storage.ErrRecipientNotFound, http.StatusNotFound},
+		{"exists", storage.ErrRecipientExists, http.StatusConflict},
+		{"invalid", fmt.Errorf("%w: alias or external_id is required", service.ErrInvalidInput), http.StatusBadRequest},
+		{"internal", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRecipients := new(mocks.RecipientUpdater)
+			mockRecipients.On("UpdateRecipient", int64(1), mock.AnythingOfType("models.RecipientUpdate")).Return(nil, tt.err)
+
+			h := New(slog.Default(), mockRecipients, "reminder_bot")
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, newRequest("1", `{"alias": ""}`))
+
+			assert.Equal(t, tt.code, rr.Code)
+			mockRecipients.AssertExpectations(t)
+		})
+	}
+}
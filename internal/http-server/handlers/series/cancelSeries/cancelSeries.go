@@ -0,0 +1,73 @@
+package cancelSeries
+
+import (
+	"DelayedNotifier/internal/lib/api/response"
+	"DelayedNotifier/internal/lib/logger/sl"
+	"DelayedNotifier/internal/storage"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=CancelSeries
+type CancelSeries interface {
+	CancelSeries(seriesID int64) error
+}
+
+func New(log *slog.Logger, series CancelSeries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.series.cancelSeries.New"
+
+		seriesID := chi.URLParam(r, "id")
+		if seriesID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{"error": "seriesID is required"})
+			return
+		}
+
+		id, err := strconv.ParseInt(seriesID, 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{"error": "invalid seriesID"})
+			return
+		}
+
+		log = log.With(
+			slog.String("op", op),
+			slog.Int64("series_id", id),
+		)
+
+		err = series.CancelSeries(id)
+		if errors.Is(err, storage.ErrSeriesNotFound) {
+			log.Info("series not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("series not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to cancel series", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to cancel series"))
+
+			return
+		}
+
+		log.Info("series cancelled")
+
+		responseOK(w, r)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, Response{
+		Response: response.OK(),
+	})
+}
@@ -0,0 +1,72 @@
+package cancelSeries
+
+import (
+	"DelayedNotifier/internal/http-server/handlers/series/cancelSeries/mocks"
+	"DelayedNotifier/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRequest(id string) *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/series/"+id, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_CancelSeries_Success(t *testing.T) {
+	mockSeries := new(mocks.CancelSeries)
+	mockSeries.On("CancelSeries", int64(1)).Return(nil)
+
+	h := New(slog.Default(), mockSeries)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("1"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSeries.AssertExpectations(t)
+}
+
+func TestHandler_CancelSeries_InvalidID(t *testing.T) {
+	mockSeries := new(mocks.CancelSeries)
+	h := New(slog.Default(), mockSeries)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("abc"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSeries.AssertNotCalled(t, "CancelSeries")
+}
+
+func TestHandler_CancelSeries_NotFound(t *testing.T) {
+	mockSeries := new(mocks.CancelSeries)
+	mockSeries.On("CancelSeries", int64(999)).Return(storage.ErrSeriesNotFound)
+
+	h := New(slog.Default(), mockSeries)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("999"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockSeries.AssertExpectations(t)
+}
+
+func TestHandler_CancelSeries_InternalError(t *testing.T) {
+	mockSeries := new(mocks.CancelSeries)
+	mockSeries.On("CancelSeries", int64(1)).Return(errors.New("database error"))
+
+	h := New(slog.Default(), mockSeries)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newRequest("1"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	mockSeries.AssertExpectations(t)
+}
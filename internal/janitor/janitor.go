@@ -0,0 +1,51 @@
+package janitor
+
+import (
+	"DelayedNotifier/internal/service"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Janitor периодически удаляет вложения, срок хранения которых истёк.
+type Janitor struct {
+	service  *service.Service
+	log      *slog.Logger
+	interval time.Duration
+}
+
+func New(service *service.Service, log *slog.Logger, interval time.Duration) *Janitor {
+	return &Janitor{
+		service:  service,
+		log:      log,
+		interval: interval,
+	}
+}
+
+func (j *Janitor) Start(ctx context.Context) {
+	j.log.Info("Starting attachments janitor", slog.String("interval", j.interval.String()))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.log.Info("Attachments janitor stopped")
+			return
+		case <-ticker.C:
+			j.cleanup()
+		}
+	}
+}
+
+func (j *Janitor) cleanup() {
+	removed, err := j.service.CleanupAttachments()
+	if err != nil {
+		j.log.Error("Failed to clean up attachments", "error", err)
+	}
+
+	if removed > 0 {
+		j.log.Info("Expired attachments removed", slog.Int("count", removed))
+	}
+}
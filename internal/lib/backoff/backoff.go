@@ -0,0 +1,43 @@
+package backoff
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Exponential возвращает задержку base * 2^attempt, ограниченную сверху max.
+func Exponential(attempt int, base, max time.Duration) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+
+	delay := base
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if delay >= max || delay <= 0 {
+			return max
+		}
+	}
+
+	if delay > max {
+		return max
+	}
+
+	return delay
+}
+
+// WithJitter случайно сдвигает delay в пределах ±factor (factor = 0.2 — ±20%),
+// чтобы повторные попытки множества уведомлений не совпадали по времени.
+func WithJitter(delay time.Duration, factor float64) time.Duration {
+	if factor <= 0 || delay <= 0 {
+		return delay
+	}
+
+	spread := float64(delay) * factor
+	jittered := float64(delay) + spread*(2*rand.Float64()-1)
+	if jittered < 0 {
+		return 0
+	}
+
+	return time.Duration(jittered)
+}
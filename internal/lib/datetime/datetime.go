@@ -0,0 +1,97 @@
+package datetime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Layout — формат даты без смещения, который API принимал изначально.
+const Layout = "2006-01-02 15:04:05"
+
+var localLayouts = []string{
+	Layout,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+}
+
+// LoadLocation загружает часовой пояс по имени IANA ("Europe/Moscow"), "UTC" или
+// фиксированному смещению вида "+03:00". Пустое имя означает UTC.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "UTC") || name == "Z" {
+		return time.UTC, nil
+	}
+
+	if name[0] == '+' || name[0] == '-' {
+		t, err := time.Parse("-07:00", name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UTC offset %q", name)
+		}
+		_, offset := t.Zone()
+
+		return time.FixedZone(name, offset), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+
+	return loc, nil
+}
+
+// Parse разбирает дату в формате RFC 3339 со смещением или без него.
+// Дата без смещения трактуется в часовом поясе timezone (по умолчанию UTC).
+// Возвращает момент времени и часовой пояс, который следует сохранить вместе с уведомлением:
+// timezone, если он указан, иначе смещение из самой даты.
+func Parse(value, timezone string) (time.Time, string, error) {
+	loc, err := LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	value = strings.TrimSpace(value)
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		if timezone == "" {
+			timezone = OffsetName(t)
+		}
+		return t, zoneName(timezone), nil
+	}
+
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, zoneName(timezone), nil
+		}
+	}
+
+	return time.Time{}, "", fmt.Errorf("invalid date %q: expected RFC 3339 or %q", value, Layout)
+}
+
+// In переводит t в часовой пояс timezone; при неизвестном поясе возвращает UTC.
+func In(t time.Time, timezone string) time.Time {
+	loc, err := LoadLocation(timezone)
+	if err != nil {
+		return t.UTC()
+	}
+
+	return t.In(loc)
+}
+
+// OffsetName возвращает смещение t в виде "+03:00" или "UTC" для нулевого смещения.
+func OffsetName(t time.Time) string {
+	if _, offset := t.Zone(); offset == 0 {
+		return "UTC"
+	}
+
+	return t.Format("-07:00")
+}
+
+func zoneName(timezone string) string {
+	if timezone == "" {
+		return "UTC"
+	}
+
+	return timezone
+}
@@ -0,0 +1,47 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		timezone string
+		wantUTC  string
+		wantZone string
+	}{
+		{"rfc3339 with offset", "2025-08-09T23:55:00+03:00", "", "2025-08-09T20:55:00Z", "+03:00"},
+		{"rfc3339 utc", "2025-08-09T23:55:00Z", "", "2025-08-09T23:55:00Z", "UTC"},
+		{"rfc3339 keeps explicit timezone", "2025-08-09T23:55:00+03:00", "Europe/Moscow", "2025-08-09T20:55:00Z", "Europe/Moscow"},
+		{"legacy layout defaults to utc", "2025-08-09 23:55:00", "", "2025-08-09T23:55:00Z", "UTC"},
+		{"legacy layout in timezone", "2025-08-09 23:55:00", "Asia/Tokyo", "2025-08-09T14:55:00Z", "Asia/Tokyo"},
+		{"fixed offset timezone", "2025-08-09T23:55", "-05:00", "2025-08-10T04:55:00Z", "-05:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, zone, err := Parse(tt.value, tt.timezone)
+			require.NoError(t, err)
+
+			want, err := time.Parse(time.RFC3339, tt.wantUTC)
+			require.NoError(t, err)
+
+			assert.True(t, want.Equal(got), "got %s", got)
+			assert.Equal(t, tt.wantZone, zone)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, _, err := Parse("tomorrow", "")
+	assert.Error(t, err)
+
+	_, _, err = Parse("2025-08-09 23:55:00", "Mars/Olympus")
+	assert.Error(t, err)
+}
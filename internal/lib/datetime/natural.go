@@ -0,0 +1,421 @@
+package datetime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultHour — час срабатывания, если во фразе указан только день ("завтра", "next monday").
+const defaultHour = 9
+
+var delayUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+var delayPart = regexp.MustCompile(`^(\d+)([smhdw])`)
+
+// ParseDelay разбирает задержку в формате time.ParseDuration ("90m", "1h30m"),
+// а также с днями и неделями ("2d", "1w3d"). Задержка должна быть положительной.
+func ParseDelay(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		d = 0
+		rest := value
+		for rest != "" {
+			m := delayPart.FindStringSubmatch(rest)
+			if m == nil {
+				return 0, fmt.Errorf("invalid delay %q", value)
+			}
+			n, _ := strconv.Atoi(m[1])
+			d += time.Duration(n) * delayUnits[m[2]]
+			rest = rest[len(m[0]):]
+		}
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid delay %q: must be positive", value)
+	}
+
+	return d, nil
+}
+
+var weekdayWords = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday, "понедельник": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "вторник": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "среда": time.Wednesday, "среду": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "четверг": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "пятница": time.Friday, "пятницу": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "суббота": time.Saturday, "субботу": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday, "воскресенье": time.Sunday,
+}
+
+// dayPartHours — части суток, которые можно указать вместо точного времени.
+var dayPartHours = map[string]int{
+	"morning": 9, "утром": 9,
+	"noon": 12, "полдень": 12,
+	"afternoon": 14, "днём": 14, "днем": 14,
+	"evening": 18, "вечером": 18,
+	"midnight": 0, "полночь": 0,
+}
+
+var clockPattern = regexp.MustCompile(`^(\d{1,2})(?:[:.](\d{2}))?(am|pm)?$`)
+
+type dayKind int
+
+const (
+	dayNone dayKind = iota
+	dayFixed
+	// dayWeekday — ближайший такой день недели, включая сегодняшний,
+	// если указанное время ещё не наступило.
+	dayWeekday
+)
+
+// ParseNatural разбирает описание момента времени на английском или русском языке
+// относительно now: "in 15 minutes", "tomorrow at 9am", "next friday 18:30",
+// "через 2 часа", "завтра в 9", "в пятницу в 7 вечера". Календарные значения
+// вычисляются в часовом поясе now.
+func ParseNatural(phrase string, now time.Time) (time.Time, error) {
+	p := newPhraseParser(phrase)
+	if p.done() {
+		return time.Time{}, fmt.Errorf("empty time phrase")
+	}
+
+	if p.accept("now", "сейчас") && p.done() {
+		return now, nil
+	}
+
+	if p.accept("in", "через") {
+		t, err := p.relative(now)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time phrase %q: %w", phrase, err)
+		}
+		if !p.done() {
+			return time.Time{}, fmt.Errorf("invalid time phrase %q: unexpected %q", phrase, p.peek())
+		}
+		return t, nil
+	}
+
+	day, kind := p.day(now)
+
+	hour, minute, hasClock, err := p.clock()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time phrase %q: %w", phrase, err)
+	}
+
+	// Время может стоять перед днём: "at 9 tomorrow", "в 9 завтра".
+	if kind == dayNone {
+		day, kind = p.day(now)
+	}
+
+	if !p.done() {
+		return time.Time{}, fmt.Errorf("invalid time phrase %q: unexpected %q", phrase, p.peek())
+	}
+
+	if kind == dayNone && !hasClock {
+		return time.Time{}, fmt.Errorf("invalid time phrase %q", phrase)
+	}
+
+	if !hasClock {
+		hour, minute = defaultHour, 0
+	}
+
+	if kind == dayNone {
+		day = now
+	}
+
+	t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+
+	switch kind {
+	case dayNone:
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+	case dayWeekday:
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 7)
+		}
+	}
+
+	return t, nil
+}
+
+var wordPattern = regexp.MustCompile(`\S+`)
+
+// SplitNatural отделяет от начала input момент времени — фразу, как в ParseNatural,
+// или задержку, как в ParseDelay, — и возвращает его вместе с остатком строки:
+// "tomorrow at 9 call mom" → завтра 9:00 и "call mom". Берётся самое длинное подходящее начало.
+func SplitNatural(input string, now time.Time) (time.Time, string, error) {
+	words := wordPattern.FindAllStringIndex(input, -1)
+
+	for n := len(words); n > 0; n-- {
+		phrase := input[:words[n-1][1]]
+		rest := strings.TrimSpace(input[words[n-1][1]:])
+
+		if t, err := ParseNatural(phrase, now); err == nil {
+			return t, rest, nil
+		}
+
+		if n == 1 {
+			if d, err := ParseDelay(phrase); err == nil {
+				return now.Add(d), rest, nil
+			}
+		}
+	}
+
+	return time.Time{}, "", fmt.Errorf("no time phrase at the start of %q", input)
+}
+
+type phraseParser struct {
+	tokens []string
+	pos    int
+}
+
+func newPhraseParser(phrase string) *phraseParser {
+	phrase = strings.ToLower(phrase)
+	phrase = strings.NewReplacer(",", " ", "a.m.", "am", "p.m.", "pm").Replace(phrase)
+
+	return &phraseParser{tokens: strings.Fields(phrase)}
+}
+
+func (p *phraseParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *phraseParser) peek() string {
+	if p.done() {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *phraseParser) accept(words ...string) bool {
+	for _, w := range words {
+		if p.peek() == w {
+			p.pos++
+			return true
+		}
+	}
+
+	return false
+}
+
+// relative разбирает одну или несколько частей задержки: "2 hours and 30 minutes",
+// "an hour", "90m", "час", "полчаса", "1 день и 2 часа".
+func (p *phraseParser) relative(now time.Time) (time.Time, error) {
+	t := now
+	parts := 0
+
+	for !p.done() {
+		if parts > 0 {
+			p.accept("and", "и")
+		}
+
+		token := p.peek()
+
+		// Компактная запись: "90m", "1h30m", "2d".
+		if !isAmount(token) {
+			if d, err := ParseDelay(token); err == nil {
+				p.pos++
+				t = t.Add(d)
+				parts++
+				continue
+			}
+		}
+
+		if p.accept("полчаса") {
+			t = t.Add(30 * time.Minute)
+			parts++
+			continue
+		}
+
+		if p.accept("half") {
+			p.accept("an", "a")
+			if unitOf(p.peek()) != "h" {
+				return time.Time{}, fmt.Errorf("expected hour after half")
+			}
+			p.pos++
+			t = t.Add(30 * time.Minute)
+			parts++
+			continue
+		}
+
+		amount := 1
+		switch {
+		case p.accept("a", "an", "one", "одну", "один", "одна"):
+		default:
+			if n, err := strconv.Atoi(token); err == nil {
+				if n <= 0 {
+					return time.Time{}, fmt.Errorf("amount must be positive")
+				}
+				amount = n
+				p.pos++
+			}
+		}
+
+		unit := unitOf(p.peek())
+		if unit == "" {
+			return time.Time{}, fmt.Errorf("expected time unit, got %q", p.peek())
+		}
+		p.pos++
+
+		switch unit {
+		case "d":
+			t = t.AddDate(0, 0, amount)
+		case "w":
+			t = t.AddDate(0, 0, 7*amount)
+		case "mo":
+			t = t.AddDate(0, amount, 0)
+		default:
+			t = t.Add(time.Duration(amount) * delayUnits[unit])
+		}
+		parts++
+	}
+
+	if parts == 0 {
+		return time.Time{}, fmt.Errorf("expected delay")
+	}
+
+	return t, nil
+}
+
+// day разбирает день: сегодня, завтра, послезавтра или день недели.
+// Возвращает полночь этого дня в часовом поясе now.
+func (p *phraseParser) day(now time.Time) (time.Time, dayKind) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	start := p.pos
+
+	switch {
+	case p.accept("today", "сегодня"):
+		return today, dayFixed
+	case p.accept("tomorrow", "завтра"):
+		return today.AddDate(0, 0, 1), dayFixed
+	case p.accept("послезавтра"):
+		return today.AddDate(0, 0, 2), dayFixed
+	case p.accept("day"):
+		if p.accept("after") && p.accept("tomorrow") {
+			return today.AddDate(0, 0, 2), dayFixed
+		}
+		p.pos = start
+		return time.Time{}, dayNone
+	}
+
+	p.accept("on", "в", "во")
+	next := p.accept("next", "следующий", "следующую", "следующее", "следующая")
+
+	weekday, ok := weekdayWords[p.peek()]
+	if !ok {
+		p.pos = start
+		return time.Time{}, dayNone
+	}
+	p.pos++
+
+	ahead := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if next {
+		if ahead == 0 {
+			ahead = 7
+		}
+		return today.AddDate(0, 0, ahead), dayFixed
+	}
+
+	return today.AddDate(0, 0, ahead), dayWeekday
+}
+
+// clock разбирает время суток: "at 9", "9:30pm", "в 18.30", "в 7 вечера", "noon", "утром".
+func (p *phraseParser) clock() (hour, minute int, ok bool, err error) {
+	start := p.pos
+	p.accept("at", "в", "к")
+
+	if h, found := dayPartHours[p.peek()]; found {
+		p.pos++
+		return h, 0, true, nil
+	}
+
+	m := clockPattern.FindStringSubmatch(p.peek())
+	if m == nil {
+		p.pos = start
+		return 0, 0, false, nil
+	}
+	p.pos++
+
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+
+	meridiem := m[3]
+	if meridiem == "" {
+		p.accept("o'clock", "час", "часа", "часов")
+		switch {
+		case p.accept("am", "утра", "ночи"):
+			meridiem = "am"
+		case p.accept("pm", "дня", "вечера"):
+			meridiem = "pm"
+		}
+	}
+
+	switch meridiem {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false, fmt.Errorf("invalid 12-hour clock value %d", hour)
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, 0, false, fmt.Errorf("invalid time of day %s", m[0])
+	}
+
+	return hour, minute, true, nil
+}
+
+func isAmount(token string) bool {
+	_, err := strconv.Atoi(token)
+	return err == nil
+}
+
+// unitOf возвращает единицу времени для слова на английском или русском языке
+// ("s", "m", "h", "d", "w", "mo") или пустую строку.
+func unitOf(token string) string {
+	switch token {
+	case "s", "sec", "secs", "second", "seconds":
+		return "s"
+	case "m", "min", "mins", "minute", "minutes":
+		return "m"
+	case "h", "hr", "hrs", "hour", "hours":
+		return "h"
+	case "d", "day", "days", "день", "дня", "дней", "сутки", "суток":
+		return "d"
+	case "w", "week", "weeks":
+		return "w"
+	case "month", "months":
+		return "mo"
+	}
+
+	switch {
+	case strings.HasPrefix(token, "сек"):
+		return "s"
+	case strings.HasPrefix(token, "мин"):
+		return "m"
+	case strings.HasPrefix(token, "час"):
+		return "h"
+	case strings.HasPrefix(token, "недел"):
+		return "w"
+	case strings.HasPrefix(token, "месяц"):
+		return "mo"
+	}
+
+	return ""
+}
@@ -0,0 +1,133 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDelay(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"90m", 90 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"45s", 45 * time.Second},
+		{"2d", 48 * time.Hour},
+		{"1w1d", 8 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDelay(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	for _, value := range []string{"", "abc", "-5m", "0s", "5x"} {
+		_, err := ParseDelay(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseNatural(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	// Пятница, 8 августа 2025 года, 14:20 по Москве.
+	now := time.Date(2025, 8, 8, 14, 20, 0, 0, loc)
+
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{"now", "2025-08-08T14:20:00+03:00"},
+		{"in 15 minutes", "2025-08-08T14:35:00+03:00"},
+		{"in an hour", "2025-08-08T15:20:00+03:00"},
+		{"in half an hour", "2025-08-08T14:50:00+03:00"},
+		{"in 2 hours and 30 minutes", "2025-08-08T16:50:00+03:00"},
+		{"in 90m", "2025-08-08T15:50:00+03:00"},
+		{"in 3 days", "2025-08-11T14:20:00+03:00"},
+		{"tomorrow at 9", "2025-08-09T09:00:00+03:00"},
+		{"tomorrow at 9:30pm", "2025-08-09T21:30:00+03:00"},
+		{"Tomorrow", "2025-08-09T09:00:00+03:00"},
+		{"today at 18:00", "2025-08-08T18:00:00+03:00"},
+		{"at 10am", "2025-08-09T10:00:00+03:00"},
+		{"at 16:00", "2025-08-08T16:00:00+03:00"},
+		{"day after tomorrow at noon", "2025-08-10T12:00:00+03:00"},
+		{"next friday 18:30", "2025-08-15T18:30:00+03:00"},
+		{"friday at 18:30", "2025-08-08T18:30:00+03:00"},
+		{"friday at 10", "2025-08-15T10:00:00+03:00"},
+		{"on monday", "2025-08-11T09:00:00+03:00"},
+		{"at 9 tomorrow", "2025-08-09T09:00:00+03:00"},
+		{"через 15 минут", "2025-08-08T14:35:00+03:00"},
+		{"через час", "2025-08-08T15:20:00+03:00"},
+		{"через полчаса", "2025-08-08T14:50:00+03:00"},
+		{"через 1 день и 2 часа", "2025-08-09T16:20:00+03:00"},
+		{"через 2 недели", "2025-08-22T14:20:00+03:00"},
+		{"завтра в 9", "2025-08-09T09:00:00+03:00"},
+		{"сегодня в 7 вечера", "2025-08-08T19:00:00+03:00"},
+		{"послезавтра в 10:15", "2025-08-10T10:15:00+03:00"},
+		{"в понедельник в 8 утра", "2025-08-11T08:00:00+03:00"},
+		{"в следующую пятницу", "2025-08-15T09:00:00+03:00"},
+		{"в 12 ночи", "2025-08-09T00:00:00+03:00"},
+		{"завтра вечером", "2025-08-09T18:00:00+03:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			got, err := ParseNatural(tt.phrase, now)
+			require.NoError(t, err)
+
+			want, err := time.Parse(time.RFC3339, tt.want)
+			require.NoError(t, err)
+
+			assert.True(t, want.Equal(got), "got %s", got.Format(time.RFC3339))
+		})
+	}
+}
+
+func TestParseNatural_Invalid(t *testing.T) {
+	now := time.Date(2025, 8, 8, 14, 20, 0, 0, time.UTC)
+
+	for _, phrase := range []string{"", "someday", "in", "in 5 parsecs", "tomorrow at 25:00", "at 13pm", "tomorrow please"} {
+		_, err := ParseNatural(phrase, now)
+		assert.Error(t, err, phrase)
+	}
+}
+
+func TestSplitNatural(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 8, 8, 14, 20, 0, 0, loc)
+
+	tests := []struct {
+		input string
+		want  string
+		rest  string
+	}{
+		{"in 15 minutes call Alice", "2025-08-08T14:35:00+03:00", "call Alice"},
+		{"tomorrow at 9 standup\nbring notes", "2025-08-09T09:00:00+03:00", "standup\nbring notes"},
+		{"tomorrow at 9, call mom", "2025-08-09T09:00:00+03:00", "call mom"},
+		{"90m stretch", "2025-08-08T15:50:00+03:00", "stretch"},
+		{"через 2 часа позвонить маме", "2025-08-08T16:20:00+03:00", "позвонить маме"},
+		{"tomorrow", "2025-08-09T09:00:00+03:00", ""},
+	}
+
+	for _, tt := range tests {
+		got, rest, err := SplitNatural(tt.input, now)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got.Format(time.RFC3339), tt.input)
+		assert.Equal(t, tt.rest, rest, tt.input)
+	}
+}
+
+func TestSplitNatural_Invalid(t *testing.T) {
+	for _, input := range []string{"", "call mom", "buy milk in 5 minutes"} {
+		_, _, err := SplitNatural(input, time.Now())
+		assert.Error(t, err, input)
+	}
+}
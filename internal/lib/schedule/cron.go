@@ -0,0 +1,190 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var weekdayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// Cron — расписание в формате crontab из пяти полей: минута, час, день месяца, месяц, день недели.
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+	// domAny и dowAny нужны для правила crontab: если ограничены и день месяца,
+	// и день недели, срабатывание происходит при совпадении любого из них.
+	domAny, dowAny bool
+	loc            *time.Location
+}
+
+// ParseCron разбирает выражение crontab. Поддерживаются *, списки, диапазоны, шаги,
+// названия месяцев и дней недели, а также макросы @daily, @weekly и т.п.
+func ParseCron(expr string, loc *time.Location) (*Cron, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, got %d", len(fields))
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	c := &Cron{loc: loc}
+
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid day of month field: %w", err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if c.dow, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("invalid day of week field: %w", err)
+	}
+
+	// 7 — альтернативное обозначение воскресенья.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+		c.dow &^= 1 << 7
+	}
+
+	c.domAny = strings.HasPrefix(fields[2], "*")
+	c.dowAny = strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+func (c *Cron) Next(after time.Time) time.Time {
+	t := after.In(c.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(0, 0, maxSearchDays)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.loc)
+			continue
+		}
+
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			rangePart, step = part[:i], s
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = min, max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(bounds[1], names); err != nil {
+				return 0, err
+			}
+		default:
+			v, err := parseCronValue(rangePart, names)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = v, v
+			// "5/15" означает «начиная с 5 с шагом 15».
+			if step > 1 {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(value)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+
+	return v, nil
+}
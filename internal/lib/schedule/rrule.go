@@ -0,0 +1,363 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FreqHourly  = "HOURLY"
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+type byDay struct {
+	weekday time.Weekday
+	// n — порядковый номер дня недели в месяце (или годе): 1MO — первый понедельник,
+	// -1FR — последняя пятница. 0 — любой такой день недели.
+	n int
+}
+
+// RRule — подмножество правила повторения iCalendar (RFC 5545): FREQ, INTERVAL, COUNT, UNTIL,
+// BYMONTH, BYMONTHDAY, BYDAY (в том числе с порядковым номером), BYHOUR, BYMINUTE.
+type RRule struct {
+	Freq     string
+	Interval int
+	// Count и Until — ограничения из самого правила. Их соблюдение — задача вызывающего кода,
+	// так как счётчик срабатываний хранится вместе с серией.
+	Count int
+	Until *time.Time
+
+	byMonth    []int
+	byMonthDay []int
+	byDay      []byDay
+	byHour     []int
+	byMinute   []int
+
+	dtstart time.Time
+}
+
+// ParseRRule разбирает правило вида "FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9".
+// dtstart задаёт начало серии, часовой пояс и значения по умолчанию для времени срабатывания.
+func ParseRRule(rule string, dtstart time.Time) (*RRule, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("empty rrule")
+	}
+
+	r := &RRule{Interval: 1, dtstart: dtstart.Truncate(time.Second)}
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(value)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval <= 0 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count <= 0 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "UNTIL":
+			var until time.Time
+			until, err = parseRRuleTime(value, dtstart.Location())
+			r.Until = &until
+		case "BYMONTH":
+			r.byMonth, err = parseIntList(value, 1, 12)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseIntList(value, -31, 31)
+		case "BYHOUR":
+			r.byHour, err = parseIntList(value, 0, 23)
+		case "BYMINUTE":
+			r.byMinute, err = parseIntList(value, 0, 59)
+		case "BYDAY":
+			r.byDay, err = parseByDay(value)
+		case "WKST":
+			// Неделя всегда начинается с понедельника.
+		default:
+			err = fmt.Errorf("unsupported rule part")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid rrule %s: %w", key, err)
+		}
+	}
+
+	switch r.Freq {
+	case FreqHourly, FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	case "":
+		return nil, fmt.Errorf("rrule FREQ is required")
+	default:
+		return nil, fmt.Errorf("unsupported rrule FREQ %q", r.Freq)
+	}
+
+	if r.Count > 0 && r.Until != nil {
+		return nil, fmt.Errorf("rrule COUNT and UNTIL are mutually exclusive")
+	}
+
+	if len(r.byMinute) == 0 {
+		r.byMinute = []int{r.dtstart.Minute()}
+	}
+	if len(r.byHour) == 0 && r.Freq != FreqHourly {
+		r.byHour = []int{r.dtstart.Hour()}
+	}
+
+	return r, nil
+}
+
+func (r *RRule) Next(after time.Time) time.Time {
+	loc := r.dtstart.Location()
+
+	from := after.In(loc)
+	if from.Before(r.dtstart) {
+		from = r.dtstart.Add(-time.Second)
+	}
+
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+
+	for i := 0; i < maxSearchDays; i++ {
+		if r.dayMatches(day) {
+			for _, t := range r.timesOfDay(day) {
+				if t.After(from) && !t.Before(r.dtstart) {
+					if r.Until != nil && t.After(*r.Until) {
+						return time.Time{}
+					}
+					return t
+				}
+			}
+		}
+
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+	}
+
+	return time.Time{}
+}
+
+func (r *RRule) timesOfDay(day time.Time) []time.Time {
+	hours := r.byHour
+	if r.Freq == FreqHourly {
+		hours = nil
+		for h := 0; h < 24; h++ {
+			if len(r.byHour) > 0 && !containsInt(r.byHour, h) {
+				continue
+			}
+			hoursSince := int(time.Date(day.Year(), day.Month(), day.Day(), h, 0, 0, 0, day.Location()).
+				Sub(r.dtstart.Truncate(time.Hour)).Hours())
+			if hoursSince%r.Interval == 0 {
+				hours = append(hours, h)
+			}
+		}
+	}
+
+	times := make([]time.Time, 0, len(hours)*len(r.byMinute))
+	for _, h := range hours {
+		for _, m := range r.byMinute {
+			times = append(times, time.Date(day.Year(), day.Month(), day.Day(), h, m, r.dtstart.Second(), 0, day.Location()))
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	return times
+}
+
+func (r *RRule) dayMatches(day time.Time) bool {
+	start := time.Date(r.dtstart.Year(), r.dtstart.Month(), r.dtstart.Day(), 0, 0, 0, 0, day.Location())
+
+	switch r.Freq {
+	case FreqDaily:
+		if daysBetween(start, day)%r.Interval != 0 {
+			return false
+		}
+	case FreqWeekly:
+		if (daysBetween(weekStart(start), weekStart(day))/7)%r.Interval != 0 {
+			return false
+		}
+		if len(r.byDay) == 0 && len(r.byMonthDay) == 0 && day.Weekday() != start.Weekday() {
+			return false
+		}
+	case FreqMonthly:
+		months := (day.Year()-start.Year())*12 + int(day.Month()) - int(start.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		if len(r.byDay) == 0 && len(r.byMonthDay) == 0 && day.Day() != start.Day() {
+			return false
+		}
+	case FreqYearly:
+		if (day.Year()-start.Year())%r.Interval != 0 {
+			return false
+		}
+		if len(r.byMonth) == 0 && len(r.byDay) == 0 && len(r.byMonthDay) == 0 &&
+			(day.Month() != start.Month() || day.Day() != start.Day()) {
+			return false
+		}
+	}
+
+	if len(r.byMonth) > 0 && !containsInt(r.byMonth, int(day.Month())) {
+		return false
+	}
+
+	if len(r.byMonth) > 0 && r.Freq == FreqYearly && len(r.byDay) == 0 && len(r.byMonthDay) == 0 &&
+		day.Day() != start.Day() {
+		return false
+	}
+
+	if len(r.byMonthDay) > 0 && !r.monthDayMatches(day) {
+		return false
+	}
+
+	if len(r.byDay) > 0 && !r.weekdayMatches(day) {
+		return false
+	}
+
+	return true
+}
+
+func (r *RRule) monthDayMatches(day time.Time) bool {
+	lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+
+	for _, md := range r.byMonthDay {
+		if md > 0 && day.Day() == md {
+			return true
+		}
+		if md < 0 && day.Day() == lastDay+md+1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *RRule) weekdayMatches(day time.Time) bool {
+	for _, bd := range r.byDay {
+		if bd.weekday != day.Weekday() {
+			continue
+		}
+
+		if bd.n == 0 {
+			return true
+		}
+
+		// Порядковый номер считается внутри месяца, а для YEARLY без BYMONTH — внутри года.
+		var first, last time.Time
+		if r.Freq == FreqYearly && len(r.byMonth) == 0 {
+			first = time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, day.Location())
+			last = time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, day.Location())
+		} else {
+			first = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+			last = time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location())
+		}
+
+		if bd.n > 0 && daysBetween(first, day)/7+1 == bd.n {
+			return true
+		}
+		if bd.n < 0 && daysBetween(day, last)/7+1 == -bd.n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseRRuleTime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if strings.HasSuffix(layout, "Z") {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t, nil
+			}
+			continue
+		}
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			if layout == "20060102" {
+				// Дата без времени включает весь день.
+				t = t.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+func parseIntList(value string, min, max int) ([]int, error) {
+	var out []int
+	for _, raw := range strings.Split(value, ",") {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < min || v > max || v == 0 && min < 0 {
+			return nil, fmt.Errorf("invalid value %q", raw)
+		}
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+func parseByDay(value string) ([]byDay, error) {
+	var out []byDay
+	for _, raw := range strings.Split(strings.ToUpper(value), ",") {
+		if len(raw) < 2 {
+			return nil, fmt.Errorf("invalid value %q", raw)
+		}
+
+		weekday, ok := rruleWeekdays[raw[len(raw)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", raw)
+		}
+
+		bd := byDay{weekday: weekday}
+		if prefix := raw[:len(raw)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n > 53 || n < -53 {
+				return nil, fmt.Errorf("invalid ordinal %q", raw)
+			}
+			bd.n = n
+		}
+
+		out = append(out, bd)
+	}
+
+	return out, nil
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// daysBetween считает календарные дни между полуночами, не завися от перехода на летнее время.
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(b.Sub(a).Hours() / 24)
+}
+
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return time.Date(day.Year(), day.Month(), day.Day()-offset, 0, 0, 0, 0, day.Location())
+}
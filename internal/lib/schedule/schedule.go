@@ -0,0 +1,14 @@
+package schedule
+
+import "time"
+
+// maxSearchDays ограничивает поиск следующего срабатывания, чтобы выражения,
+// которые никогда не срабатывают (например, 30 февраля), не зацикливали поиск.
+const maxSearchDays = 366 * 5
+
+// Schedule вычисляет моменты срабатывания повторяющегося уведомления.
+type Schedule interface {
+	// Next возвращает первое срабатывание строго после after
+	// или нулевое время, если срабатываний больше нет.
+	Next(after time.Time) time.Time
+}
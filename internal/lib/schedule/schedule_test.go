@@ -0,0 +1,115 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func TestCron_Next(t *testing.T) {
+	tests := []struct {
+		name  string
+		expr  string
+		after string
+		want  string
+	}{
+		{"every weekday at 09:00 skips weekend", "0 9 * * 1-5", "2025-08-08T10:00:00Z", "2025-08-11T09:00:00Z"},
+		{"step minutes", "*/15 * * * *", "2025-08-08T10:07:00Z", "2025-08-08T10:15:00Z"},
+		{"names", "30 8 1 JAN,JUL *", "2025-02-01T00:00:00Z", "2025-07-01T08:30:00Z"},
+		{"sunday as 7", "0 0 * * 7", "2025-08-08T00:00:00Z", "2025-08-10T00:00:00Z"},
+		{"dom or dow when both restricted", "0 12 15 * MON", "2025-08-12T00:00:00Z", "2025-08-15T12:00:00Z"},
+		{"macro", "@monthly", "2025-08-08T00:00:00Z", "2025-09-01T00:00:00Z"},
+		{"strictly after", "0 9 * * *", "2025-08-08T09:00:00Z", "2025-08-09T09:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.expr, time.UTC)
+			require.NoError(t, err)
+
+			assert.Equal(t, mustTime(t, tt.want), c.Next(mustTime(t, tt.after)))
+		})
+	}
+}
+
+func TestCron_NextRespectsLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	c, err := ParseCron("0 9 * * *", loc)
+	require.NoError(t, err)
+
+	assert.Equal(t, mustTime(t, "2025-08-09T06:00:00Z"), c.Next(mustTime(t, "2025-08-08T07:00:00Z")).UTC())
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "61 * * * *", "* * * FOO *", "*/0 * * * *", "5-1 * * * *"} {
+		_, err := ParseCron(expr, time.UTC)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCron_NeverFires(t *testing.T) {
+	c, err := ParseCron("0 0 30 2 *", time.UTC)
+	require.NoError(t, err)
+
+	assert.True(t, c.Next(mustTime(t, "2025-01-01T00:00:00Z")).IsZero())
+}
+
+func TestRRule_Next(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart string
+		after   string
+		want    string
+	}{
+		{"weekdays", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", "2025-08-01T09:00:00Z", "2025-08-08T09:00:00Z", "2025-08-11T09:00:00Z"},
+		{"first monday of month", "RRULE:FREQ=MONTHLY;BYDAY=1MO", "2025-08-01T10:00:00Z", "2025-08-04T10:00:00Z", "2025-09-01T10:00:00Z"},
+		{"last friday of month", "FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=18;BYMINUTE=0", "2025-08-01T00:00:00Z", "2025-08-01T00:00:00Z", "2025-08-29T18:00:00Z"},
+		{"every other day", "FREQ=DAILY;INTERVAL=2", "2025-08-01T08:00:00Z", "2025-08-01T08:00:00Z", "2025-08-03T08:00:00Z"},
+		{"last day of month", "FREQ=MONTHLY;BYMONTHDAY=-1", "2025-01-31T12:00:00Z", "2025-01-31T12:00:00Z", "2025-02-28T12:00:00Z"},
+		{"yearly", "FREQ=YEARLY", "2024-03-15T07:30:00Z", "2024-03-15T07:30:00Z", "2025-03-15T07:30:00Z"},
+		{"every 6 hours", "FREQ=HOURLY;INTERVAL=6", "2025-08-01T01:00:00Z", "2025-08-01T01:00:00Z", "2025-08-01T07:00:00Z"},
+		{"before dtstart returns dtstart", "FREQ=DAILY", "2025-08-10T09:00:00Z", "2025-08-01T00:00:00Z", "2025-08-10T09:00:00Z"},
+		{"biweekly", "FREQ=WEEKLY;INTERVAL=2;BYDAY=WE", "2025-08-06T09:00:00Z", "2025-08-06T09:00:00Z", "2025-08-20T09:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRRule(tt.rule, mustTime(t, tt.dtstart))
+			require.NoError(t, err)
+
+			assert.Equal(t, mustTime(t, tt.want), r.Next(mustTime(t, tt.after)))
+		})
+	}
+}
+
+func TestRRule_CountAndUntil(t *testing.T) {
+	r, err := ParseRRule("FREQ=DAILY;COUNT=3", mustTime(t, "2025-08-01T09:00:00Z"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, r.Count)
+
+	r, err = ParseRRule("FREQ=DAILY;UNTIL=20250803T090000Z", mustTime(t, "2025-08-01T09:00:00Z"))
+	require.NoError(t, err)
+	assert.Equal(t, mustTime(t, "2025-08-03T09:00:00Z"), r.Next(mustTime(t, "2025-08-02T09:00:00Z")))
+	assert.True(t, r.Next(mustTime(t, "2025-08-03T09:00:00Z")).IsZero())
+}
+
+func TestParseRRule_Invalid(t *testing.T) {
+	for _, rule := range []string{"", "BYDAY=MO", "FREQ=SECONDLY", "FREQ=DAILY;INTERVAL=0", "FREQ=WEEKLY;BYDAY=XX", "FREQ=DAILY;COUNT=2;UNTIL=20250101"} {
+		_, err := ParseRRule(rule, time.Now())
+		assert.Error(t, err, rule)
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"io"
+	"time"
+)
+
+// Виды вложений: фото отправляется через sendPhoto, остальные файлы — через sendDocument.
+const (
+	AttachmentPhoto    = "photo"
+	AttachmentDocument = "document"
+)
+
+// Attachment — файл, который отправляется вместе с уведомлением: загруженный в хранилище
+// (BlobKey) или доступный по ссылке (URL).
+type Attachment struct {
+	ID          int64     `json:"id"`
+	Kind        string    `json:"kind"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type,omitempty"`
+	Size        int64     `json:"size,omitempty"`
+	BlobKey     string    `json:"-"`
+	URL         string    `json:"url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewAttachment — вложение из запроса: содержимое загруженного файла или ссылка на него.
+// Пустой Kind определяется по типу содержимого и имени файла.
+type NewAttachment struct {
+	Kind        string
+	FileName    string
+	ContentType string
+	URL         string
+	Content     io.Reader
+}
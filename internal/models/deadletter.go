@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// DeadLetter — сообщение из очереди недоставленных уведомлений.
+type DeadLetter struct {
+	MessageID      string    `json:"message_id"`
+	NotificationID int64     `json:"notification_id,omitempty"`
+	Body           string    `json:"body"`
+	Reason         string    `json:"reason"`
+	FailedAt       time.Time `json:"failed_at"`
+}
@@ -0,0 +1,46 @@
+package models
+
+// MaxEscalationSteps ограничивает длину политики эскалации.
+const MaxEscalationSteps = 10
+
+// Состояния эскалации уведомления.
+const (
+	// EscalationWaiting — уведомление ещё не доставлено, отсчёт не начат.
+	EscalationWaiting = "waiting"
+	// EscalationActive — запланирован следующий шаг.
+	EscalationActive       = "active"
+	EscalationAcknowledged = "acknowledged"
+	// EscalationExhausted — все шаги выполнены, а подтверждения так и нет.
+	EscalationExhausted = "exhausted"
+)
+
+// EscalationStep — шаг политики эскалации. Если уведомление не подтверждено в течение After
+// после доставки (или после предыдущего шага), оно отправляется получателям Recipients,
+// а если список пуст — повторно исходному получателю.
+type EscalationStep struct {
+	After      string             `json:"after"`
+	Recipients []EscalationTarget `json:"recipients,omitempty"`
+}
+
+// EscalationTarget — получатель шага эскалации: recipient_id для Telegram или адрес в канале Channel.
+type EscalationTarget struct {
+	RecipientID int64  `json:"recipient_id,omitempty"`
+	Channel     string `json:"channel,omitempty"`
+	Address     string `json:"address,omitempty"`
+}
+
+// EscalationState возвращает состояние эскалации или пустую строку, если политики нет.
+func (n *Notification) EscalationState() string {
+	switch {
+	case len(n.Escalation) == 0:
+		return ""
+	case n.AcknowledgedAt != nil:
+		return EscalationAcknowledged
+	case n.EscalateAt != nil:
+		return EscalationActive
+	case n.EscalationLevel >= len(n.Escalation):
+		return EscalationExhausted
+	default:
+		return EscalationWaiting
+	}
+}
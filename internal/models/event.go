@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// События истории доставки уведомления.
+const (
+	EventCreated  = "created"
+	EventRetry    = "retry"
+	EventFallback = "fallback"
+	EventSent     = "sent"
+	EventFailed   = "failed"
+	EventReset    = "reset"
+	// EventAcknowledged и EventSnoozed — реакция получателя кнопками под сообщением.
+	EventAcknowledged = "acknowledged"
+	EventSnoozed      = "snoozed"
+	// EventEscalated — выполнен шаг эскалации неподтверждённого уведомления.
+	EventEscalated = "escalated"
+	// EventChatMigrated — группа Telegram стала супергруппой, уведомление переадресовано в новый чат.
+	EventChatMigrated = "chat_migrated"
+)
+
+// NotificationEvent — запись истории уведомления: смена статуса, попытка отправки
+// или переход на резервный канал. Channel — канал, к которому относится событие.
+type NotificationEvent struct {
+	ID             int64     `json:"id"`
+	NotificationID int64     `json:"notification_id"`
+	Event          string    `json:"event"`
+	Status         string    `json:"status"`
+	Channel        string    `json:"channel"`
+	Attempt        int       `json:"attempt,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -1,11 +1,99 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
+const (
+	StatusPending  = "pending"
+	StatusInFlight = "in_flight"
+	StatusSent     = "sent"
+	StatusFailed   = "failed"
+	// StatusPartial — длинное сообщение доставлено не полностью: попытки кончились после части сообщений.
+	StatusPartial = "partial"
+	// StatusAcknowledged и StatusSnoozed — получатель нажал под доставленным сообщением «Done» или «Snooze».
+	StatusAcknowledged = "acknowledged"
+	StatusSnoozed      = "snoozed"
+)
+
+// Notification — отложенное уведомление.
+// Metadata — произвольный JSON-объект, который передаётся получателю вебхука.
+// Fallback — резервные каналы в порядке очереди, RouteIndex — текущий канал:
+// 0 — основной (Channel, Address), n — Fallback[n-1].
+// SentParts — число частей длинного сообщения, уже доставленных через текущий канал,
+// MessageIDs — идентификаторы всех отправленных сообщений в порядке отправки.
+// AcknowledgedAt — момент, когда получатель отметил уведомление выполненным или отложил его.
+// Escalation — политика эскалации, EscalationLevel — число выполненных шагов, EscalateAt — срок следующего шага;
+// EscalationOf — уведомление, эскалацией которого создано это, Escalations — созданные эскалацией уведомления.
+// OwnerChatID — чат, который создал уведомление командой бота; только он видит и отменяет его через бота.
 type Notification struct {
 	ID          int64     `json:"id"`
 	RecipientID int64     `json:"recipient_id"`
+	Channel     string    `json:"channel"`
+	Address     string    `json:"address,omitempty"`
+	Fallback    []Route   `json:"fallback,omitempty"`
+	RouteIndex  int       `json:"route_index"`
 	Date        time.Time `json:"date"`
+	Timezone    string    `json:"timezone"`
 	Text        string    `json:"text"`
-	Status      string    `json:"status"`
+	MessageOptions
+	Metadata        json.RawMessage  `json:"metadata,omitempty"`
+	Status          string           `json:"status"`
+	Attempts        int              `json:"attempts"`
+	NextAttemptAt   *time.Time       `json:"next_attempt_at,omitempty"`
+	LastError       string           `json:"last_error,omitempty"`
+	SeriesID        *int64           `json:"series_id,omitempty"`
+	AttachmentID    *int64           `json:"attachment_id,omitempty"`
+	SentParts       int              `json:"sent_parts"`
+	MessageIDs      []int64          `json:"message_ids,omitempty"`
+	AcknowledgedAt  *time.Time       `json:"acknowledged_at,omitempty"`
+	Escalation      []EscalationStep `json:"escalation,omitempty"`
+	EscalationLevel int              `json:"escalation_level"`
+	EscalateAt      *time.Time       `json:"escalate_at,omitempty"`
+	EscalationOf    *int64           `json:"escalation_of,omitempty"`
+	Escalations     []int64          `json:"escalations,omitempty"`
+	OwnerChatID     *int64           `json:"owner_chat_id,omitempty"`
+}
+
+// MessageOptions — параметры оформления сообщения в Telegram.
+// ParseMode — plain, MarkdownV2 или HTML; DisableNotification отправляет сообщение без звука.
+type MessageOptions struct {
+	ParseMode             string `json:"parse_mode,omitempty"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview,omitempty"`
+	DisableNotification   bool   `json:"disable_notification,omitempty"`
+}
+
+// Route — канал доставки и адрес получателя в нём.
+type Route struct {
+	Channel string `json:"channel"`
+	Address string `json:"address,omitempty"`
+}
+
+// Route возвращает канал, через который уведомление отправляется сейчас.
+func (n *Notification) Route() Route {
+	if n.RouteIndex > 0 && n.RouteIndex <= len(n.Fallback) {
+		return n.Fallback[n.RouteIndex-1]
+	}
+
+	return Route{Channel: n.Channel, Address: n.Address}
+}
+
+// NextRoute возвращает следующий резервный канал, если он есть.
+func (n *Notification) NextRoute() (Route, bool) {
+	if n.RouteIndex >= len(n.Fallback) {
+		return Route{}, false
+	}
+
+	return n.Fallback[n.RouteIndex], true
+}
+
+// DueAt возвращает момент, когда уведомление нужно попытаться отправить:
+// дату отправки или время следующей повторной попытки.
+func (n *Notification) DueAt() time.Time {
+	if n.NextAttemptAt != nil {
+		return *n.NextAttemptAt
+	}
+
+	return n.Date
 }
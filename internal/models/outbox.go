@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type OutboxMessage struct {
+	ID             int64     `json:"id"`
+	NotificationID int64     `json:"notification_id"`
+	DeliverAt      time.Time `json:"deliver_at"`
+	Attempts       int       `json:"attempts"`
+}
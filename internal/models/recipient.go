@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Recipient — получатель из реестра. Вызывающие сервисы адресуют его по Alias или ExternalID,
+// а ChatID Telegram привязывается, когда пользователь открывает бота по ссылке с OnboardingToken.
+type Recipient struct {
+	ID         int64  `json:"id"`
+	Alias      string `json:"alias,omitempty"`
+	ExternalID string `json:"external_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	ChatID     *int64 `json:"chat_id,omitempty"`
+	Active     bool   `json:"active"`
+	// DeactivatedReason — почему получатель выключен автоматически, например бот заблокирован в Telegram.
+	DeactivatedReason string     `json:"deactivated_reason,omitempty"`
+	OnboardingToken   string     `json:"onboarding_token,omitempty"`
+	TokenExpiresAt    *time.Time `json:"token_expires_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// RecipientUpdate — изменения получателя; nil означает «не менять», пустая строка очищает поле.
+// RotateToken выпускает новую ссылку для привязки чата.
+type RecipientUpdate struct {
+	Alias       *string
+	ExternalID  *string
+	Name        *string
+	ChatID      *int64
+	Active      *bool
+	RotateToken bool
+}
+
+// NewRecipient — параметры создания получателя, полученные от API.
+// Нужен хотя бы один из Alias и ExternalID; ChatID можно указать сразу, иначе чат привязывается по ссылке.
+type NewRecipient struct {
+	Alias      string
+	ExternalID string
+	Name       string
+	ChatID     *int64
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ReconcileReport описывает результат одного прохода реконсилятора.
+// Recovered — уведомления, которые застряли и опубликованы заново, Requeued — ещё не наступившие,
+// опубликованные заранее на случай, если очередь потеряла их сообщения.
+type ReconcileReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Scanned    int       `json:"scanned"`
+	Recovered  []int64   `json:"recovered"`
+	Requeued   []int64   `json:"requeued"`
+	Failed     []int64   `json:"failed"`
+	Error      string    `json:"error,omitempty"`
+}
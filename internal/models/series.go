@@ -0,0 +1,71 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ScheduleCron  = "cron"
+	ScheduleRRule = "rrule"
+)
+
+// Series — повторяющееся уведомление. Каждое срабатывание серии — отдельная запись в notifications.
+type Series struct {
+	ID          int64   `json:"id"`
+	RecipientID int64   `json:"recipient_id"`
+	Channel     string  `json:"channel"`
+	Address     string  `json:"address,omitempty"`
+	Fallback    []Route `json:"fallback,omitempty"`
+	Text        string  `json:"text"`
+	MessageOptions
+	Metadata     json.RawMessage  `json:"metadata,omitempty"`
+	AttachmentID *int64           `json:"attachment_id,omitempty"`
+	Escalation   []EscalationStep `json:"escalation,omitempty"`
+	Kind         string           `json:"kind"`
+	Expression   string           `json:"expression"`
+	StartAt      time.Time        `json:"start_at"`
+	Timezone     string           `json:"timezone"`
+	Until        *time.Time       `json:"until,omitempty"`
+	MaxCount     int              `json:"max_count,omitempty"`
+	Occurrences  int              `json:"occurrences"`
+	Active       bool             `json:"active"`
+}
+
+// NewNotification — параметры создания уведомления, полученные от API.
+type NewNotification struct {
+	RecipientID int64
+	// Recipient — alias или external_id получателя из реестра; если задан, RecipientID берётся из реестра.
+	Recipient string
+	// Channel — канал доставки ("telegram", "email", "webhook", "slack", "discord"); пустой означает канал по умолчанию.
+	// Address — адрес получателя в каналах, где он не задаётся RecipientID.
+	Channel string
+	Address string
+	// Fallback — резервные каналы; пустой список означает резервные каналы получателя из конфигурации.
+	Fallback []Route
+	// Date, Delay и When — взаимоисключающие способы задать дату:
+	// абсолютная дата, задержка ("90m") или фраза ("tomorrow at 9", "через час").
+	Date     string
+	Delay    string
+	When     string
+	Timezone string
+	Text     string
+	Options  MessageOptions
+	Metadata json.RawMessage
+	// Attachment — необязательный файл, который Telegram отправит с текстом в подписи.
+	Attachment *NewAttachment
+	// Escalation — шаги эскалации, если уведомление не подтверждено кнопкой под сообщением.
+	Escalation []EscalationStep
+	Schedule   *ScheduleParams
+	// OwnerChatID — чат, создавший уведомление командой бота; 0 — уведомление создано через API.
+	OwnerChatID int64
+}
+
+// ScheduleParams описывает повторение: ровно одно из Cron и RRule,
+// а также необязательные дата окончания и число срабатываний.
+type ScheduleParams struct {
+	Cron  string
+	RRule string
+	Until string
+	Count int
+}
@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"DelayedNotifier/internal/service"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Relay публикует в RabbitMQ сообщения, записанные в outbox вместе с уведомлениями.
+// Неудачные публикации остаются в outbox и повторяются с экспоненциальной задержкой.
+type Relay struct {
+	service  *service.Service
+	log      *slog.Logger
+	interval time.Duration
+}
+
+func New(service *service.Service, log *slog.Logger, interval time.Duration) *Relay {
+	return &Relay{
+		service:  service,
+		log:      log,
+		interval: interval,
+	}
+}
+
+func (r *Relay) Start(ctx context.Context) {
+	r.log.Info("Starting outbox relay", slog.String("interval", r.interval.String()))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+			r.relay()
+		}
+	}
+}
+
+func (r *Relay) relay() {
+	for {
+		messages, err := r.service.ClaimOutboxMessages()
+		if err != nil {
+			r.log.Error("Failed to claim outbox messages", "error", err)
+			return
+		}
+
+		if len(messages) == 0 {
+			return
+		}
+
+		for i := range messages {
+			err = r.service.PublishOutboxMessage(&messages[i])
+			if err != nil {
+				r.log.Error("Failed to publish outbox message", "error", err,
+					"outbox_id", messages[i].ID, "notification_id", messages[i].NotificationID)
+				// Брокер, скорее всего, недоступен — оставшиеся сообщения дождутся следующего тика.
+				return
+			}
+		}
+	}
+}
@@ -1,28 +1,287 @@
 package broker
 
 import (
+	"DelayedNotifier/internal/config"
+	"DelayedNotifier/internal/lib/backoff"
+	"DelayedNotifier/internal/models"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/streadway/amqp"
 )
 
+const (
+	delayedExchange     = "notifications.delayed"
+	delayedExchangeType = "x-delayed-message"
+
+	headerDelay     = "x-delay"
+	headerDeliverAt = "x-deliver-at"
+
+	headerFailureReason = "x-failure-reason"
+	headerFailedAt      = "x-failed-at"
+
+	// maxPluginDelay — предельная задержка, которую принимает плагин x-delayed-message (2^32-1 мс).
+	maxPluginDelay = time.Duration(1<<32-1) * time.Millisecond
+
+	// Задержка между попытками переподключения растёт от reconnectBase до reconnectMax.
+	reconnectBase = time.Second
+	reconnectMax  = 30 * time.Second
+)
+
+// waitTiers — уровни задержки очередей ожидания, которые используются без плагина.
+// Сообщение кладётся в очередь с наибольшим уровнем, не превышающим оставшееся время,
+// и после истечения TTL возвращается в основную очередь через dead-letter exchange.
+var waitTiers = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	15 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+}
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// ErrUnavailable означает, что соединения с RabbitMQ сейчас нет: брокер переподключается.
+// Публикация в это время не буферизуется и возвращает эту ошибку.
+var ErrUnavailable = errors.New("rabbitmq is unavailable")
+
+// ErrNotConfirmed означает, что RabbitMQ не подтвердил публикацию: отверг сообщение
+// или не ответил за config.Publisher.ConfirmTimeout. Сообщение могло не сохраниться.
+var ErrNotConfirmed = errors.New("publish was not confirmed")
+
+// ErrUnroutable означает, что RabbitMQ вернул сообщение: ни одна очередь его не приняла.
+var ErrUnroutable = errors.New("message is unroutable")
+
+// ErrPoolExhausted означает, что все каналы пула заняты дольше config.Publisher.ConfirmTimeout.
+// Сообщение не отправлялось.
+var ErrPoolExhausted = errors.New("publisher pool is exhausted")
+
+// RabbitMQBroker держит одно соединение с RabbitMQ. Если соединение обрывается, брокер
+// переподключается с растущей задержкой, заново объявляет очереди и возобновляет потребителей;
+// каналы, возвращённые Consume, при этом не закрываются.
 type RabbitMQBroker struct {
-	conn *amqp.Connection
+	url string
+	log *slog.Logger
+
+	mu            sync.RWMutex
+	conn          *amqp.Connection
+	delayedPlugin bool
+	queues        []string
+	consumers     []*consumer
+	closed        bool
+	done          chan struct{}
+	wg            sync.WaitGroup
+
+	publishCfg config.Publisher
+	// publishers — пул каналов публикации; nil в пуле означает свободное место под новый канал.
+	publishers chan *publisher
 }
 
-func New(url string) (*RabbitMQBroker, error) {
+// publisher — долгоживущий канал публикации в режиме подтверждений. Канал выдаётся одному
+// отправителю за раз, поэтому следующее подтверждение всегда относится к его сообщению.
+type publisher struct {
+	conn     *amqp.Connection
+	ch       publishChannel
+	confirms chan amqp.Confirmation
+	returns  chan amqp.Return
+	closed   chan *amqp.Error
+}
+
+// publishChannel — операции канала AMQP, которыми пользуется публикация. Подтверждения и возвраты
+// приходят в каналы publisher, поэтому без RabbitMQ их можно подать из теста.
+type publishChannel interface {
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	Close() error
+}
+
+// consumer — потребитель очереди, который переживает переподключения: сообщения каждого
+// нового соединения пересылаются в один и тот же канал out.
+type consumer struct {
+	queue    string
+	prefetch int
+	out      chan amqp.Delivery
+}
+
+func New(url string, cfg config.Publisher, log *slog.Logger) (*RabbitMQBroker, error) {
 	conn, err := amqp.Dial(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
-	return &RabbitMQBroker{conn: conn}, nil
+	if cfg.Channels < 1 {
+		cfg.Channels = 1
+	}
+
+	b := &RabbitMQBroker{
+		url:        url,
+		log:        log,
+		done:       make(chan struct{}),
+		publishCfg: cfg,
+		publishers: make(chan *publisher, cfg.Channels),
+	}
+	for i := 0; i < cfg.Channels; i++ {
+		b.publishers <- nil
+	}
+	b.setConn(conn)
+
+	return b, nil
+}
+
+// DelayedPlugin сообщает, используется ли плагин rabbitmq_delayed_message_exchange.
+func (b *RabbitMQBroker) DelayedPlugin() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.delayedPlugin
+}
+
+// setConn делает conn текущим соединением и следит за его закрытием. Вызывается под b.mu.
+func (b *RabbitMQBroker) setConn(conn *amqp.Connection) {
+	b.conn = conn
+	go b.watch(conn)
+}
+
+// watch ждёт закрытия conn и, если это не штатное закрытие брокера, запускает переподключение.
+func (b *RabbitMQBroker) watch(conn *amqp.Connection) {
+	closeErr := <-conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	b.mu.Lock()
+	// Соединение уже заменено: например, при объявлении очередей без плагина задержки.
+	if b.closed || b.conn != conn {
+		b.mu.Unlock()
+		return
+	}
+	b.conn = nil
+	b.mu.Unlock()
+
+	b.log.Error("RabbitMQ connection lost, reconnecting", "error", closeErr)
+
+	b.reconnect()
+}
+
+// reconnect подключается заново, пока не получится или пока брокер не закрыт.
+func (b *RabbitMQBroker) reconnect() {
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-b.done:
+			return
+		case <-time.After(backoff.Exponential(attempt, reconnectBase, reconnectMax)):
+		}
+
+		conn, err := amqp.Dial(b.url)
+		if err != nil {
+			b.log.Error("Failed to reconnect to RabbitMQ", "error", err, "attempt", attempt+1)
+			continue
+		}
+
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+
+		for _, queueName := range b.queues {
+			if conn, err = b.declareTopology(conn, queueName); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			b.mu.Unlock()
+			_ = conn.Close()
+			b.log.Error("Failed to restore RabbitMQ topology", "error", err, "attempt", attempt+1)
+			continue
+		}
+
+		b.setConn(conn)
+		consumers := b.consumers
+		b.mu.Unlock()
+
+		for _, c := range consumers {
+			if err = b.startConsumer(conn, c); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			// Закрытие соединения снова запустит переподключение через watch.
+			b.log.Error("Failed to resume RabbitMQ consumers", "error", err)
+			_ = conn.Close()
+			return
+		}
+
+		b.log.Info("RabbitMQ connection restored", slog.Int("attempts", attempt+1))
+
+		return
+	}
+}
+
+// connection возвращает текущее соединение или ErrUnavailable, если брокер переподключается.
+func (b *RabbitMQBroker) connection() (*amqp.Connection, error) {
+	b.mu.RLock()
+	conn := b.conn
+	b.mu.RUnlock()
+
+	if conn == nil || conn.IsClosed() {
+		return nil, ErrUnavailable
+	}
+
+	return conn, nil
 }
 
+// channel открывает канал на текущем соединении.
+func (b *RabbitMQBroker) channel() (*amqp.Channel, error) {
+	conn, err := b.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open a channel: %v", ErrUnavailable, err)
+	}
+
+	return ch, nil
+}
+
+// DeclareQueue объявляет очередь, её очередь недоставленных и топологию отложенной доставки.
+// После переподключения топология объявляется заново.
 func (b *RabbitMQBroker) DeclareQueue(queueName string) error {
-	ch, err := b.conn.Channel()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.conn == nil {
+		return ErrUnavailable
+	}
+
+	conn, err := b.declareTopology(b.conn, queueName)
+	if conn != b.conn {
+		b.setConn(conn)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to open a channel: %w", err)
+		return err
+	}
+
+	b.queues = append(b.queues, queueName)
+
+	return nil
+}
+
+// declareTopology объявляет очереди на conn. Возвращает соединение, которым нужно пользоваться
+// дальше: без плагина задержки RabbitMQ закрывает исходное, и оно открывается заново.
+func (b *RabbitMQBroker) declareTopology(conn *amqp.Connection, queueName string) (*amqp.Connection, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return conn, fmt.Errorf("failed to open a channel: %w", err)
 	}
 	defer func(ch *amqp.Channel) {
 		err = ch.Close()
@@ -40,14 +299,46 @@ func (b *RabbitMQBroker) DeclareQueue(queueName string) error {
 		nil,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to declare a queue: %w", err)
+		return conn, fmt.Errorf("failed to declare a queue: %w", err)
 	}
 
-	return nil
+	_, err = ch.QueueDeclare(
+		deadLetterQueueName(queueName),
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return conn, fmt.Errorf("failed to declare a dead-letter queue: %w", err)
+	}
+
+	return b.declareDelayedTopology(conn, queueName)
 }
 
-func (b *RabbitMQBroker) Publish(queueName string, message []byte) error {
-	ch, err := b.conn.Channel()
+func (b *RabbitMQBroker) declareDelayedTopology(conn *amqp.Connection, queueName string) (*amqp.Connection, error) {
+	if err := declarePluginExchange(conn, queueName); err == nil {
+		b.delayedPlugin = true
+		return conn, nil
+	}
+
+	// Неизвестный тип exchange RabbitMQ считает ошибкой соединения и закрывает его.
+	if conn.IsClosed() {
+		reopened, err := amqp.Dial(b.url)
+		if err != nil {
+			return conn, fmt.Errorf("failed to reconnect to RabbitMQ: %w", err)
+		}
+		conn = reopened
+	}
+
+	b.delayedPlugin = false
+
+	return conn, declareWaitQueues(conn, queueName)
+}
+
+func declarePluginExchange(conn *amqp.Connection, queueName string) error {
+	ch, err := conn.Channel()
 	if err != nil {
 		return fmt.Errorf("failed to open a channel: %w", err)
 	}
@@ -58,30 +349,389 @@ func (b *RabbitMQBroker) Publish(queueName string, message []byte) error {
 		}
 	}(ch)
 
-	err = ch.Publish(
-		"",
-		queueName,
+	err = ch.ExchangeDeclare(
+		delayedExchange,
+		delayedExchangeType,
+		true,
+		false,
+		false,
 		false,
+		amqp.Table{"x-delayed-type": "direct"},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare delayed exchange: %w", err)
+	}
+
+	err = ch.QueueBind(queueName, queueName, delayedExchange, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to bind queue to delayed exchange: %w", err)
+	}
+
+	return nil
+}
+
+func declareWaitQueues(conn *amqp.Connection, queueName string) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open a channel: %w", err)
+	}
+	defer func(ch *amqp.Channel) {
+		err = ch.Close()
+		if err != nil {
+			return
+		}
+	}(ch)
+
+	for _, tier := range waitTiers {
+		_, err = ch.QueueDeclare(
+			waitQueueName(queueName, tier),
+			true,
+			false,
+			false,
+			false,
+			amqp.Table{
+				"x-message-ttl":             tier.Milliseconds(),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": queueName,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare a wait queue: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (b *RabbitMQBroker) Publish(queueName string, message []byte) error {
+	return b.publish("", queueName, message, nil)
+}
+
+// PublishDelayed публикует сообщение так, чтобы оно попало в очередь queueName не раньше deliverAt.
+func (b *RabbitMQBroker) PublishDelayed(queueName string, message []byte, deliverAt time.Time) error {
+	headers := amqp.Table{headerDeliverAt: deliverAt.UnixMilli()}
+
+	delay := time.Until(deliverAt)
+	if delay <= 0 {
+		return b.publish("", queueName, message, headers)
+	}
+
+	if b.DelayedPlugin() {
+		if delay > maxPluginDelay {
+			delay = maxPluginDelay
+		}
+		headers[headerDelay] = delay.Milliseconds()
+
+		return b.publish(delayedExchange, queueName, message, headers)
+	}
+
+	return b.publish("", waitQueueName(queueName, pickWaitTier(delay)), message, headers)
+}
+
+// PublishDeadLetter кладёт сообщение в очередь недоставленных уведомлений с причиной ошибки в заголовках.
+func (b *RabbitMQBroker) PublishDeadLetter(queueName string, message []byte, reason string) error {
+	headers := amqp.Table{
+		headerFailureReason: reason,
+		headerFailedAt:      time.Now().UnixMilli(),
+	}
+
+	return b.publish("", deadLetterQueueName(queueName), message, headers)
+}
+
+// ListDeadLetters возвращает до limit сообщений из очереди недоставленных уведомлений, не удаляя их.
+func (b *RabbitMQBroker) ListDeadLetters(queueName string, limit int) ([]models.DeadLetter, error) {
+	ch, err := b.channel()
+	if err != nil {
+		return nil, err
+	}
+	// Закрытие канала возвращает все неподтверждённые сообщения в очередь.
+	defer func(ch *amqp.Channel) {
+		err = ch.Close()
+		if err != nil {
+			return
+		}
+	}(ch)
+
+	letters := make([]models.DeadLetter, 0)
+	for len(letters) < limit {
+		d, ok, err := ch.Get(deadLetterQueueName(queueName), false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get a dead letter: %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		letters = append(letters, toDeadLetter(d))
+	}
+
+	return letters, nil
+}
+
+// ReplayDeadLetters возвращает сообщения из очереди недоставленных уведомлений в основную очередь.
+// Если messageID не пуст, возвращается только сообщение с этим ID. Перед повторной публикацией
+// вызывается prepare; если он возвращает ошибку, сообщение остаётся в очереди недоставленных.
+func (b *RabbitMQBroker) ReplayDeadLetters(queueName, messageID string, prepare func(models.DeadLetter) error) (int, error) {
+	ch, err := b.channel()
+	if err != nil {
+		return 0, err
+	}
+	defer func(ch *amqp.Channel) {
+		err = ch.Close()
+		if err != nil {
+			return
+		}
+	}(ch)
+
+	dlq, err := ch.QueueInspect(deadLetterQueueName(queueName))
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect a dead-letter queue: %w", err)
+	}
+
+	replayed := 0
+	for i := 0; i < dlq.Messages; i++ {
+		d, ok, err := ch.Get(dlq.Name, false)
+		if err != nil {
+			return replayed, fmt.Errorf("failed to get a dead letter: %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		letter := toDeadLetter(d)
+		if messageID != "" && letter.MessageID != messageID {
+			continue
+		}
+
+		if err = prepare(letter); err != nil {
+			return replayed, fmt.Errorf("failed to prepare dead letter %s: %w", letter.MessageID, err)
+		}
+
+		// Сообщение удаляется из очереди недоставленных только после подтверждения публикации.
+		if err = b.publish("", queueName, d.Body, nil); err != nil {
+			return replayed, fmt.Errorf("failed to republish a dead letter: %w", err)
+		}
+
+		if err = d.Ack(false); err != nil {
+			return replayed, fmt.Errorf("failed to ack a dead letter: %w", err)
+		}
+
+		replayed++
+
+		if messageID != "" {
+			return replayed, nil
+		}
+	}
+
+	if messageID != "" {
+		return 0, ErrDeadLetterNotFound
+	}
+
+	return replayed, nil
+}
+
+// PurgeDeadLetters удаляет все сообщения из очереди недоставленных уведомлений.
+func (b *RabbitMQBroker) PurgeDeadLetters(queueName string) (int, error) {
+	ch, err := b.channel()
+	if err != nil {
+		return 0, err
+	}
+	defer func(ch *amqp.Channel) {
+		err = ch.Close()
+		if err != nil {
+			return
+		}
+	}(ch)
+
+	purged, err := ch.QueuePurge(deadLetterQueueName(queueName), false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge a dead-letter queue: %w", err)
+	}
+
+	return purged, nil
+}
+
+// publish публикует сохраняемое сообщение и ждёт подтверждения RabbitMQ не дольше ConfirmTimeout.
+func (b *RabbitMQBroker) publish(exchange, routingKey string, message []byte, headers amqp.Table) error {
+	p, err := b.acquire()
+	if err != nil {
+		return err
+	}
+
+	// Плагин задержки маршрутизирует сообщение только по истечении задержки
+	// и возвращает любое сообщение с флагом mandatory.
+	mandatory := exchange != delayedExchange
+
+	err = p.ch.Publish(
+		exchange,
+		routingKey,
+		mandatory,
 		false,
 		amqp.Publishing{
-			ContentType: "text/plain",
-			Body:        message,
+			Headers:      headers,
+			ContentType:  "text/plain",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    newMessageID(),
+			Timestamp:    time.Now(),
+			Body:         message,
 		})
 	if err != nil {
+		// Канал мог остаться открытым, а место в пуле освобождается, поэтому закрываем его сами.
+		_ = p.ch.Close()
+		b.release(nil)
 		return fmt.Errorf("failed to publish a message: %w", err)
 	}
 
-	return nil
+	timer := time.NewTimer(b.publishCfg.ConfirmTimeout)
+	defer timer.Stop()
+
+	select {
+	case confirm, ok := <-p.confirms:
+		if !ok {
+			b.release(nil)
+			return fmt.Errorf("%w: channel closed before confirmation", ErrUnavailable)
+		}
+
+		// basic.return приходит раньше подтверждения того же сообщения.
+		select {
+		case ret, ok := <-p.returns:
+			if ok {
+				b.release(p)
+				return fmt.Errorf("%w: %s (%d)", ErrUnroutable, ret.ReplyText, ret.ReplyCode)
+			}
+		default:
+		}
+
+		b.release(p)
+
+		if !confirm.Ack {
+			return fmt.Errorf("%w: message was rejected", ErrNotConfirmed)
+		}
+
+		return nil
+	case <-timer.C:
+		// Опоздавшее подтверждение нельзя отличить от подтверждения следующего сообщения,
+		// поэтому канал больше не используется.
+		_ = p.ch.Close()
+		b.release(nil)
+
+		return fmt.Errorf("%w: no confirmation within %s", ErrNotConfirmed, b.publishCfg.ConfirmTimeout)
+	}
 }
 
-func (b *RabbitMQBroker) Consume(queueName string) (<-chan amqp.Delivery, error) {
-	ch, err := b.conn.Channel()
+// acquire забирает канал из пула, открывая новый вместо закрытого или открытого на прежнем соединении.
+// Канал возвращается в пул через release.
+func (b *RabbitMQBroker) acquire() (*publisher, error) {
+	conn, err := b.connection()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open a channel: %w", err)
+		return nil, err
+	}
+
+	timer := time.NewTimer(b.publishCfg.ConfirmTimeout)
+	defer timer.Stop()
+
+	var p *publisher
+	select {
+	case p = <-b.publishers:
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: no free publishing channel within %s", ErrPoolExhausted, b.publishCfg.ConfirmTimeout)
+	case <-b.done:
+		return nil, ErrUnavailable
+	}
+
+	if p != nil && p.conn == conn && !p.isClosed() {
+		return p, nil
+	}
+	if p != nil {
+		_ = p.ch.Close()
+	}
+
+	p, err = newPublisher(conn)
+	if err != nil {
+		b.release(nil)
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// release возвращает канал в пул; nil освобождает место, и следующий acquire откроет новый канал.
+func (b *RabbitMQBroker) release(p *publisher) {
+	b.publishers <- p
+}
+
+func newPublisher(conn *amqp.Connection) (*publisher, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open a channel: %v", ErrUnavailable, err)
+	}
+
+	if err = ch.Confirm(false); err != nil {
+		_ = ch.Close()
+		return nil, fmt.Errorf("failed to put channel into confirm mode: %w", err)
+	}
+
+	return &publisher{
+		conn:     conn,
+		ch:       ch,
+		confirms: ch.NotifyPublish(make(chan amqp.Confirmation, 1)),
+		returns:  ch.NotifyReturn(make(chan amqp.Return, 1)),
+		closed:   ch.NotifyClose(make(chan *amqp.Error, 1)),
+	}, nil
+}
+
+// isClosed сообщает, закрыт ли канал: RabbitMQ закрывает канал, например, при публикации в несуществующий exchange.
+func (p *publisher) isClosed() bool {
+	select {
+	case <-p.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Consume возвращает только те сообщения, время доставки которых уже наступило.
+// Сообщения, вернувшиеся из очереди ожидания раньше срока, откладываются повторно.
+// prefetch ограничивает число сообщений, выданных потребителю и ещё не подтверждённых.
+// После переподключения потребитель возобновляется сам, а канал закрывается только в Close.
+func (b *RabbitMQBroker) Consume(queueName string, prefetch int) (<-chan amqp.Delivery, error) {
+	conn, err := b.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &consumer{
+		queue:    queueName,
+		prefetch: prefetch,
+		out:      make(chan amqp.Delivery),
+	}
+
+	if err = b.startConsumer(conn, c); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.consumers = append(b.consumers, c)
+	b.mu.Unlock()
+
+	return c.out, nil
+}
+
+// startConsumer подписывает c на очередь в новом канале conn и пересылает сообщения в c.out,
+// пока канал не закроется вместе с соединением.
+func (b *RabbitMQBroker) startConsumer(conn *amqp.Connection, c *consumer) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open a channel: %w", err)
+	}
+
+	if err = ch.Qos(c.prefetch, 0, false); err != nil {
+		_ = ch.Close()
+		return fmt.Errorf("failed to set channel prefetch: %w", err)
 	}
 
 	msgs, err := ch.Consume(
-		queueName,
+		c.queue,
 		"",
 		false,
 		false,
@@ -90,12 +740,130 @@ func (b *RabbitMQBroker) Consume(queueName string) (<-chan amqp.Delivery, error)
 		nil,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register a consumer: %w", err)
+		_ = ch.Close()
+		return fmt.Errorf("failed to register a consumer: %w", err)
 	}
 
-	return msgs, nil
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		for d := range msgs {
+			deliverAt, ok := deliverAtHeader(d.Headers)
+			if ok && time.Now().Before(deliverAt) {
+				err := b.PublishDelayed(c.queue, d.Body, deliverAt)
+				if err == nil {
+					if err = d.Ack(false); err != nil {
+						b.log.Error("Failed to ack re-delayed message", "error", err, "queue", c.queue)
+					}
+					continue
+				}
+
+				// Возврат в очередь сразу же привёл бы сообщение обратно, поэтому его получает воркер:
+				// он сверит срок с базой и отложит уведомление сам или вернёт сообщение в очередь.
+				b.log.Error("Failed to re-delay early message, handing it to the worker",
+					"error", err, "queue", c.queue, "deliver_at", deliverAt)
+			}
+
+			select {
+			case c.out <- d:
+			case <-b.done:
+				return
+			}
+		}
+	}()
+
+	return nil
 }
 
+// Close закрывает соединение без переподключения и закрывает каналы потребителей.
 func (b *RabbitMQBroker) Close() error {
-	return b.conn.Close()
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.done)
+	conn := b.conn
+	consumers := b.consumers
+	b.mu.Unlock()
+
+	var err error
+	if conn != nil {
+		err = conn.Close()
+	}
+
+	b.wg.Wait()
+	for _, c := range consumers {
+		close(c.out)
+	}
+
+	return err
+}
+
+func deadLetterQueueName(queueName string) string {
+	return queueName + ".dlq"
+}
+
+func newMessageID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+
+	return hex.EncodeToString(buf)
+}
+
+func toDeadLetter(d amqp.Delivery) models.DeadLetter {
+	letter := models.DeadLetter{
+		MessageID: d.MessageId,
+		Body:      string(d.Body),
+	}
+
+	if reason, ok := d.Headers[headerFailureReason].(string); ok {
+		letter.Reason = reason
+	}
+
+	switch v := d.Headers[headerFailedAt].(type) {
+	case int64:
+		letter.FailedAt = time.UnixMilli(v).UTC()
+	case int32:
+		letter.FailedAt = time.UnixMilli(int64(v)).UTC()
+	}
+
+	if id, err := strconv.ParseInt(letter.Body, 10, 64); err == nil {
+		letter.NotificationID = id
+	}
+
+	return letter
+}
+
+func waitQueueName(queueName string, tier time.Duration) string {
+	return fmt.Sprintf("%s.wait.%d", queueName, tier.Milliseconds())
+}
+
+// pickWaitTier выбирает наибольший уровень, не превышающий delay.
+// Задержки короче минимального уровня округляются до него.
+func pickWaitTier(delay time.Duration) time.Duration {
+	tier := waitTiers[0]
+	for _, t := range waitTiers {
+		if t > delay {
+			break
+		}
+		tier = t
+	}
+
+	return tier
+}
+
+func deliverAtHeader(headers amqp.Table) (time.Time, bool) {
+	switch v := headers[headerDeliverAt].(type) {
+	case int64:
+		return time.UnixMilli(v), true
+	case int32:
+		return time.UnixMilli(int64(v)), true
+	default:
+		return time.Time{}, false
+	}
 }
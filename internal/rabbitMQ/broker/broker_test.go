@@ -0,0 +1,344 @@
+package broker
+
+import (
+	"DelayedNotifier/internal/config"
+	"DelayedNotifier/internal/models"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type published struct {
+	exchange  string
+	key       string
+	mandatory bool
+	msg       amqp.Publishing
+}
+
+// fakeChannel записывает публикации и отвечает на них через reply так, как ответил бы RabbitMQ.
+type fakeChannel struct {
+	confirms   chan amqp.Confirmation
+	returns    chan amqp.Return
+	publishErr error
+	reply      func(ch *fakeChannel)
+	published  []published
+	closed     bool
+}
+
+func newFakeChannel(reply func(ch *fakeChannel)) *fakeChannel {
+	return &fakeChannel{
+		confirms: make(chan amqp.Confirmation, 1),
+		returns:  make(chan amqp.Return, 1),
+		reply:    reply,
+	}
+}
+
+func (c *fakeChannel) Publish(exchange, key string, mandatory, _ bool, msg amqp.Publishing) error {
+	if c.publishErr != nil {
+		return c.publishErr
+	}
+
+	c.published = append(c.published, published{exchange: exchange, key: key, mandatory: mandatory, msg: msg})
+	if c.reply != nil {
+		c.reply(c)
+	}
+
+	return nil
+}
+
+func (c *fakeChannel) Close() error {
+	c.closed = true
+	return nil
+}
+
+func ack(ch *fakeChannel) {
+	ch.confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+}
+
+func nack(ch *fakeChannel) {
+	ch.confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: false}
+}
+
+// newTestBroker возвращает брокер с открытым соединением и пулом из одного канала ch.
+func newTestBroker(ch *fakeChannel, confirmTimeout time.Duration) *RabbitMQBroker {
+	conn := &amqp.Connection{}
+
+	b := &RabbitMQBroker{
+		log:        slog.Default(),
+		conn:       conn,
+		done:       make(chan struct{}),
+		publishCfg: config.Publisher{Channels: 1, ConfirmTimeout: confirmTimeout},
+		publishers: make(chan *publisher, 1),
+	}
+	b.publishers <- &publisher{
+		conn:     conn,
+		ch:       ch,
+		confirms: ch.confirms,
+		returns:  ch.returns,
+		closed:   make(chan *amqp.Error, 1),
+	}
+
+	return b
+}
+
+// pooled забирает канал, который publish вернул в пул; nil означает освобождённое место.
+func pooled(t *testing.T, b *RabbitMQBroker) *publisher {
+	t.Helper()
+
+	select {
+	case p := <-b.publishers:
+		return p
+	default:
+		require.Fail(t, "publisher was not returned to the pool")
+		return nil
+	}
+}
+
+func TestPickWaitTier(t *testing.T) {
+	cases := []struct {
+		delay time.Duration
+		want  time.Duration
+	}{
+		{delay: 0, want: time.Second},
+		{delay: 500 * time.Millisecond, want: time.Second},
+		{delay: time.Second, want: time.Second},
+		{delay: 4 * time.Second, want: time.Second},
+		{delay: 5 * time.Second, want: 5 * time.Second},
+		{delay: 90 * time.Second, want: time.Minute},
+		{delay: 59 * time.Minute, want: 15 * time.Minute},
+		{delay: 7 * time.Hour, want: 6 * time.Hour},
+		{delay: 72 * time.Hour, want: 24 * time.Hour},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, pickWaitTier(tc.delay), tc.delay.String())
+	}
+}
+
+func TestDeliverAtHeader(t *testing.T) {
+	at := time.UnixMilli(1754772900000)
+
+	cases := []struct {
+		name    string
+		headers amqp.Table
+		want    time.Time
+		ok      bool
+	}{
+		{name: "int64", headers: amqp.Table{headerDeliverAt: at.UnixMilli()}, want: at, ok: true},
+		{name: "int32", headers: amqp.Table{headerDeliverAt: int32(1000)}, want: time.UnixMilli(1000), ok: true},
+		{name: "missing", headers: amqp.Table{}},
+		{name: "nil headers"},
+		{name: "string", headers: amqp.Table{headerDeliverAt: "1754772900000"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := deliverAtHeader(tc.headers)
+			assert.Equal(t, tc.ok, ok)
+			assert.True(t, tc.want.Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestToDeadLetter(t *testing.T) {
+	failedAt := time.Date(2025, 8, 9, 20, 55, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		delivery amqp.Delivery
+		want     models.DeadLetter
+	}{
+		{
+			name: "notification",
+			delivery: amqp.Delivery{
+				MessageId: "abc",
+				Body:      []byte("42"),
+				Headers: amqp.Table{
+					headerFailureReason: "retries exhausted",
+					headerFailedAt:      failedAt.UnixMilli(),
+				},
+			},
+			want: models.DeadLetter{MessageID: "abc", NotificationID: 42, Body: "42", Reason: "retries exhausted", FailedAt: failedAt},
+		},
+		{
+			name: "int32 timestamp",
+			delivery: amqp.Delivery{
+				MessageId: "def",
+				Body:      []byte("7"),
+				Headers:   amqp.Table{headerFailedAt: int32(1000)},
+			},
+			want: models.DeadLetter{MessageID: "def", NotificationID: 7, Body: "7", FailedAt: time.UnixMilli(1000).UTC()},
+		},
+		{
+			name:     "unparsable body",
+			delivery: amqp.Delivery{MessageId: "ghi", Body: []byte("not-a-number")},
+			want:     models.DeadLetter{MessageID: "ghi", Body: "not-a-number"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, toDeadLetter(tc.delivery))
+		})
+	}
+}
+
+func TestPublishDelayed_Plugin(t *testing.T) {
+	cases := []struct {
+		name      string
+		deliverAt time.Time
+		exchange  string
+		delay     time.Duration
+	}{
+		{name: "short delay", deliverAt: time.Now().Add(time.Hour), exchange: delayedExchange, delay: time.Hour},
+		// Плагин не принимает задержку больше 2^32-1 мс: сообщение вернётся раньше и будет отложено снова.
+		{name: "capped delay", deliverAt: time.Now().Add(90 * 24 * time.Hour), exchange: delayedExchange, delay: maxPluginDelay},
+		{name: "already due", deliverAt: time.Now().Add(-time.Minute), exchange: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ch := newFakeChannel(ack)
+			b := newTestBroker(ch, time.Second)
+			b.delayedPlugin = true
+
+			require.NoError(t, b.PublishDelayed("notifications", []byte("1"), tc.deliverAt))
+			require.Len(t, ch.published, 1)
+
+			p := ch.published[0]
+			assert.Equal(t, tc.exchange, p.exchange)
+			assert.Equal(t, "notifications", p.key)
+			assert.Equal(t, tc.deliverAt.UnixMilli(), p.msg.Headers[headerDeliverAt])
+			// Плагин маршрутизирует сообщение только по истечении задержки, поэтому mandatory с ним не используется.
+			assert.Equal(t, tc.exchange == "", p.mandatory)
+
+			if tc.delay == 0 {
+				assert.NotContains(t, p.msg.Headers, headerDelay)
+				return
+			}
+
+			delay, ok := p.msg.Headers[headerDelay].(int64)
+			require.True(t, ok)
+			assert.LessOrEqual(t, delay, maxPluginDelay.Milliseconds())
+			assert.InDelta(t, tc.delay.Milliseconds(), delay, float64(time.Second.Milliseconds()))
+		})
+	}
+}
+
+func TestPublishDelayed_WaitQueue(t *testing.T) {
+	ch := newFakeChannel(ack)
+	b := newTestBroker(ch, time.Second)
+
+	require.NoError(t, b.PublishDelayed("notifications", []byte("1"), time.Now().Add(90*time.Minute)))
+	require.Len(t, ch.published, 1)
+
+	assert.Equal(t, "", ch.published[0].exchange)
+	assert.Equal(t, waitQueueName("notifications", time.Hour), ch.published[0].key)
+	assert.True(t, ch.published[0].mandatory)
+}
+
+func TestPublish_Confirmation(t *testing.T) {
+	cases := []struct {
+		name  string
+		reply func(ch *fakeChannel)
+		err   error
+		// kept — канал остаётся в пуле; иначе он закрыт и место в пуле освобождено.
+		kept bool
+	}{
+		{name: "ack", reply: ack, kept: true},
+		{name: "nack", reply: nack, err: ErrNotConfirmed, kept: true},
+		{
+			name: "returned",
+			reply: func(ch *fakeChannel) {
+				// basic.return приходит раньше подтверждения того же сообщения.
+				ch.returns <- amqp.Return{ReplyCode: 312, ReplyText: "NO_ROUTE"}
+				ack(ch)
+			},
+			err:  ErrUnroutable,
+			kept: true,
+		},
+		{
+			name:  "channel closed",
+			reply: func(ch *fakeChannel) { close(ch.confirms) },
+			err:   ErrUnavailable,
+		},
+		{name: "no confirmation", err: ErrNotConfirmed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ch := newFakeChannel(tc.reply)
+			b := newTestBroker(ch, 20*time.Millisecond)
+
+			err := b.Publish("notifications", []byte("1"))
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+
+			require.Len(t, ch.published, 1)
+			msg := ch.published[0].msg
+			assert.Equal(t, amqp.Persistent, msg.DeliveryMode)
+			assert.NotEmpty(t, msg.MessageId)
+
+			p := pooled(t, b)
+			if tc.kept {
+				require.NotNil(t, p)
+				assert.Equal(t, ch, p.ch)
+				return
+			}
+
+			assert.Nil(t, p)
+			if tc.name == "no confirmation" {
+				// Опоздавшее подтверждение спутало бы следующую публикацию, поэтому канал закрывается.
+				assert.True(t, ch.closed)
+			}
+		})
+	}
+}
+
+func TestPublish_ClosedBroker(t *testing.T) {
+	ch := newFakeChannel(ack)
+	b := newTestBroker(ch, time.Second)
+	b.conn = nil
+
+	err := b.Publish("notifications", []byte("1"))
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Empty(t, ch.published)
+}
+
+func TestPublish_FailedPublishFreesSlot(t *testing.T) {
+	ch := newFakeChannel(ack)
+	ch.publishErr = amqp.ErrClosed
+	b := newTestBroker(ch, time.Second)
+
+	err := b.Publish("notifications", []byte("1"))
+	assert.ErrorIs(t, err, amqp.ErrClosed)
+
+	// Сломанный канал закрыт и не возвращается в пул: следующий acquire откроет новый.
+	assert.True(t, ch.closed)
+	assert.Nil(t, pooled(t, b))
+}
+
+func TestPublish_PoolExhausted(t *testing.T) {
+	ch := newFakeChannel(ack)
+	b := newTestBroker(ch, 20*time.Millisecond)
+
+	// Единственный канал пула занят другим отправителем.
+	busy := pooled(t, b)
+
+	err := b.Publish("notifications", []byte("1"))
+	assert.ErrorIs(t, err, ErrPoolExhausted)
+	assert.NotErrorIs(t, err, ErrNotConfirmed)
+	assert.Empty(t, ch.published)
+
+	// Когда канал освобождается, публикация снова проходит через него.
+	b.release(busy)
+	require.NoError(t, b.Publish("notifications", []byte("1")))
+	assert.Len(t, ch.published, 1)
+}
@@ -0,0 +1,112 @@
+package reconciler
+
+import (
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Reconciler находит pending-уведомления, которые не дошли до воркера (очередь очищена,
+// брокер пересоздан, сообщение подтверждено упавшим воркером), и публикует их заново.
+type Reconciler struct {
+	service  *service.Service
+	log      *slog.Logger
+	interval time.Duration
+
+	mu         sync.Mutex
+	lastReport *models.ReconcileReport
+}
+
+func New(service *service.Service, log *slog.Logger, interval time.Duration) *Reconciler {
+	return &Reconciler{
+		service:  service,
+		log:      log,
+		interval: interval,
+	}
+}
+
+// Start выполняет сверку сразу при запуске, а затем раз в interval.
+func (r *Reconciler) Start(ctx context.Context) {
+	r.log.Info("Starting reconciler", slog.String("interval", r.interval.String()))
+
+	r.Run()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("Reconciler stopped")
+			return
+		case <-ticker.C:
+			r.Run()
+		}
+	}
+}
+
+// Run выполняет один проход сверки и сохраняет его отчёт.
+func (r *Reconciler) Run() *models.ReconcileReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &models.ReconcileReport{
+		StartedAt: time.Now().UTC(),
+		Recovered: []int64{},
+		Requeued:  []int64{},
+		Failed:    []int64{},
+	}
+
+	notifications, err := r.service.FindStrandedNotifications()
+	if err != nil {
+		r.log.Error("Failed to find stranded notifications", "error", err)
+		report.Error = err.Error()
+	}
+
+	report.Scanned = len(notifications)
+
+	for i := range notifications {
+		err = r.service.RecoverNotification(&notifications[i])
+		if err != nil {
+			r.log.Error("Failed to recover notification", "error", err, "notification_id", notifications[i].ID)
+			report.Failed = append(report.Failed, notifications[i].ID)
+			continue
+		}
+
+		if report.StartedAt.Before(notifications[i].DueAt()) {
+			report.Requeued = append(report.Requeued, notifications[i].ID)
+			continue
+		}
+
+		report.Recovered = append(report.Recovered, notifications[i].ID)
+	}
+
+	report.FinishedAt = time.Now().UTC()
+
+	// Заранее опубликованные уведомления — обычная работа, а не признак потерь.
+	if len(report.Recovered) > 0 || len(report.Failed) > 0 {
+		r.log.Warn("Reconciler recovered stranded notifications",
+			slog.Int("scanned", report.Scanned),
+			slog.Any("recovered", report.Recovered),
+			slog.Int("requeued", len(report.Requeued)),
+			slog.Any("failed", report.Failed),
+		)
+	} else {
+		r.log.Debug("Reconciler found no stranded notifications", slog.Int("requeued", len(report.Requeued)))
+	}
+
+	r.lastReport = report
+
+	return report
+}
+
+// LastReport возвращает отчёт последнего прохода или nil, если сверка ещё не выполнялась.
+func (r *Reconciler) LastReport() *models.ReconcileReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lastReport
+}
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"DelayedNotifier/internal/service"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Poller периодически забирает из PostgreSQL уведомления, срок которых наступил, и отправляет их.
+// Используется вместо очереди RabbitMQ, когда scheduler.mode = postgres.
+type Poller struct {
+	service  *service.Service
+	log      *slog.Logger
+	interval time.Duration
+}
+
+func New(service *service.Service, log *slog.Logger, interval time.Duration) *Poller {
+	return &Poller{
+		service:  service,
+		log:      log,
+		interval: interval,
+	}
+}
+
+func (p *Poller) Start(ctx context.Context) {
+	p.log.Info("Starting Postgres scheduler", slog.String("interval", p.interval.String()))
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.log.Info("Postgres scheduler stopped")
+			return
+		case <-ticker.C:
+			p.poll()
+		}
+	}
+}
+
+func (p *Poller) poll() {
+	for {
+		notifications, err := p.service.ClaimDueNotifications()
+		if err != nil {
+			p.log.Error("Failed to claim due notifications", "error", err)
+			return
+		}
+
+		if len(notifications) == 0 {
+			return
+		}
+
+		for i := range notifications {
+			err = p.service.DeliverNotification(&notifications[i])
+			if err != nil {
+				p.log.Error("Failed to deliver notification", "error", err, "notification_id", notifications[i].ID)
+			}
+		}
+	}
+}
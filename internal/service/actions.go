@@ -0,0 +1,79 @@
+package service
+
+import (
+	"DelayedNotifier/internal/models"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotOwner означает, что действие пришло из чата, которому уведомление не адресовано.
+var ErrNotOwner = errors.New("notification belongs to another chat")
+
+// AcknowledgeNotification отмечает уведомление выполненным по нажатию кнопки в чате chatID;
+// подтверждение уведомления эскалации останавливает эскалацию исходного.
+// Если уведомление уже отмечено или отложено, возвращается storage.ErrNotifyNotActive.
+func (s *Service) AcknowledgeNotification(notificationID, chatID int64) error {
+	notification, err := s.ownNotification(notificationID, chatID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.storage.AcknowledgeNotification(notificationID, notification.Route().Channel); err != nil {
+		return err
+	}
+
+	return s.acknowledgeEscalated(notification)
+}
+
+// SnoozeNotification откладывает уведомление по нажатию кнопки в чате chatID: оно получает статус snoozed,
+// а через delay отправляется его копия с тем же текстом, вложением, каналами и эскалацией. Возвращает копию.
+// Отложенное уведомление эскалации подтверждает исходное: кто-то его увидел.
+func (s *Service) SnoozeNotification(notificationID, chatID int64, delay time.Duration) (*models.Notification, error) {
+	if delay <= 0 {
+		return nil, fmt.Errorf("%w: snooze delay must be positive", ErrInvalidInput)
+	}
+
+	notification, err := s.ownNotification(notificationID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	snoozed, err := s.storage.SnoozeNotification(notificationID, notification.Route().Channel, &models.Notification{
+		RecipientID:    notification.RecipientID,
+		Channel:        notification.Channel,
+		Address:        notification.Address,
+		Fallback:       notification.Fallback,
+		Date:           time.Now().Add(delay),
+		Timezone:       notification.Timezone,
+		Text:           notification.Text,
+		MessageOptions: notification.MessageOptions,
+		Metadata:       notification.Metadata,
+		AttachmentID:   notification.AttachmentID,
+		Escalation:     notification.Escalation,
+		OwnerChatID:    notification.OwnerChatID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service failed to snooze notification: %w", err)
+	}
+
+	if err = s.acknowledgeEscalated(notification); err != nil {
+		return nil, err
+	}
+
+	return snoozed, nil
+}
+
+// ownNotification возвращает уведомление, если оно адресовано чату chatID.
+func (s *Service) ownNotification(notificationID, chatID int64) (*models.Notification, error) {
+	notification, err := s.storage.GetNotificationByID(notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if notification.RecipientID != chatID {
+		return nil, ErrNotOwner
+	}
+
+	return notification, nil
+}
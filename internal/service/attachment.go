@@ -0,0 +1,194 @@
+package service
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/models"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// cleanupBatchSize — сколько просроченных вложений удаляется за один проход.
+const cleanupBatchSize = 100
+
+var photoTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+var blobExtension = regexp.MustCompile(`^\.[A-Za-z0-9]{1,10}$`)
+
+// saveAttachment проверяет вложение, сохраняет загруженный файл в хранилище и возвращает ID записи.
+func (s *Service) saveAttachment(params *models.NewAttachment) (*int64, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	attachment := &models.Attachment{
+		Kind:        params.Kind,
+		FileName:    params.FileName,
+		ContentType: params.ContentType,
+		URL:         params.URL,
+	}
+
+	switch {
+	case params.URL != "" && params.Content != nil:
+		return nil, fmt.Errorf("%w: attachment must be either uploaded or referenced by url", ErrInvalidInput)
+	case params.URL != "":
+		if err := channel.ValidateURL(params.URL); err != nil {
+			return nil, fmt.Errorf("%w: attachment: %v", ErrInvalidInput, err)
+		}
+		if attachment.FileName == "" {
+			if u, err := url.Parse(params.URL); err == nil {
+				attachment.FileName = path.Base(u.Path)
+			}
+		}
+	case params.Content == nil:
+		return nil, fmt.Errorf("%w: attachment requires a file or url", ErrInvalidInput)
+	}
+
+	if attachment.FileName == "" || attachment.FileName == "/" || attachment.FileName == "." {
+		attachment.FileName = "attachment"
+	}
+	if attachment.ContentType == "" {
+		attachment.ContentType = mime.TypeByExtension(strings.ToLower(filepath.Ext(attachment.FileName)))
+	}
+
+	switch attachment.Kind {
+	case "":
+		attachment.Kind = attachmentKind(attachment.ContentType)
+	case models.AttachmentPhoto, models.AttachmentDocument:
+	default:
+		return nil, fmt.Errorf("%w: unsupported attachment kind %q: expected photo or document", ErrInvalidInput, attachment.Kind)
+	}
+
+	if params.Content != nil {
+		key, err := blobKey(attachment.FileName)
+		if err != nil {
+			return nil, err
+		}
+
+		maxSize := s.cfg.Attachments.MaxSize
+		size, err := s.blobs.Put(key, io.LimitReader(params.Content, maxSize+1))
+		if err != nil {
+			_ = s.blobs.Delete(key)
+			return nil, fmt.Errorf("failed to store attachment: %w", err)
+		}
+
+		if size > maxSize {
+			_ = s.blobs.Delete(key)
+			return nil, fmt.Errorf("%w: attachment exceeds %d bytes", ErrInvalidInput, maxSize)
+		}
+
+		attachment.BlobKey = key
+		attachment.Size = size
+	}
+
+	created, err := s.storage.CreateAttachment(attachment)
+	if err != nil {
+		if attachment.BlobKey != "" {
+			_ = s.blobs.Delete(attachment.BlobKey)
+		}
+		return nil, err
+	}
+
+	return &created.ID, nil
+}
+
+// discardAttachment удаляет вложение, уведомление для которого так и не было создано.
+func (s *Service) discardAttachment(attachmentID int64) error {
+	attachment, err := s.storage.GetAttachment(attachmentID)
+	if err != nil {
+		return err
+	}
+
+	return s.removeAttachment(attachment)
+}
+
+// CleanupAttachments удаляет вложения, срок хранения которых истёк, вместе с их содержимым.
+func (s *Service) CleanupAttachments() (int, error) {
+	removed := 0
+
+	for {
+		attachments, err := s.storage.ExpiredAttachments(s.cfg.Attachments.Retention, cleanupBatchSize)
+		if err != nil {
+			return removed, err
+		}
+
+		for i := range attachments {
+			if err = s.removeAttachment(&attachments[i]); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+
+		if len(attachments) < cleanupBatchSize {
+			return removed, nil
+		}
+	}
+}
+
+// removeAttachment удаляет сначала содержимое, затем запись: если удаление записи не удастся,
+// следующий проход повторит его, а повторное удаление отсутствующего файла ошибкой не считается.
+func (s *Service) removeAttachment(attachment *models.Attachment) error {
+	if attachment.BlobKey != "" {
+		if err := s.blobs.Delete(attachment.BlobKey); err != nil {
+			return err
+		}
+	}
+
+	return s.storage.DeleteAttachment(attachment.ID)
+}
+
+// channelAttachment готовит вложение к отправке: содержимое читается из хранилища только при отправке.
+func (s *Service) channelAttachment(attachment *models.Attachment) *channel.Attachment {
+	result := &channel.Attachment{
+		Kind:        attachment.Kind,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		URL:         attachment.URL,
+	}
+
+	if attachment.BlobKey != "" {
+		key := attachment.BlobKey
+		result.Open = func() (io.ReadCloser, error) {
+			return s.blobs.Open(key)
+		}
+	}
+
+	return result
+}
+
+// attachmentKind отправляет как фото только форматы, которые Telegram принимает в sendPhoto.
+func attachmentKind(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if photoTypes[mediaType] {
+		return models.AttachmentPhoto
+	}
+
+	return models.AttachmentDocument
+}
+
+// blobKey генерирует случайный ключ хранилища, сохраняя расширение исходного файла.
+func blobKey(fileName string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate attachment key: %w", err)
+	}
+
+	key := hex.EncodeToString(buf)
+	if ext := filepath.Ext(fileName); blobExtension.MatchString(ext) {
+		key += strings.ToLower(ext)
+	}
+
+	return key, nil
+}
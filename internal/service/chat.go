@@ -0,0 +1,94 @@
+package service
+
+import (
+	"DelayedNotifier/internal/lib/datetime"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/telegram/notifier"
+	"fmt"
+	"time"
+)
+
+// chatListLimit — сколько ближайших напоминаний показывает команда /list.
+const chatListLimit = 20
+
+// ChatTimezone возвращает часовой пояс, выбранный чатом, по умолчанию UTC.
+func (s *Service) ChatTimezone(chatID int64) (string, error) {
+	timezone, err := s.storage.GetChatTimezone(chatID)
+	if err != nil {
+		return "", err
+	}
+
+	if timezone == "" {
+		return time.UTC.String(), nil
+	}
+
+	return timezone, nil
+}
+
+// SetChatTimezone проверяет и сохраняет часовой пояс чата, возвращает его имя в том виде, в каком оно сохранено.
+func (s *Service) SetChatTimezone(chatID int64, zone string) (string, error) {
+	loc, err := datetime.LoadLocation(zone)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if err = s.storage.SetChatTimezone(chatID, loc.String()); err != nil {
+		return "", err
+	}
+
+	return loc.String(), nil
+}
+
+// CreateChatReminder создаёт напоминание по команде /remind: input начинается с момента времени
+// в часовом поясе чата ("in 15 minutes", "tomorrow at 9", "90m"), за которым идёт текст.
+// Напоминание приходит в тот же чат и принадлежит ему.
+func (s *Service) CreateChatReminder(chatID int64, input string) (*models.Notification, error) {
+	timezone, err := s.ChatTimezone(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := datetime.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	date, text, err := parseReminder(input, time.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateNotification(models.NewNotification{
+		RecipientID: chatID,
+		Channel:     notifier.Name,
+		Date:        date.Format(time.RFC3339),
+		Timezone:    timezone,
+		Text:        text,
+		OwnerChatID: chatID,
+	})
+}
+
+// parseReminder делит ввод /remind на момент времени относительно now и текст напоминания.
+func parseReminder(input string, now time.Time) (time.Time, string, error) {
+	date, text, err := datetime.SplitNatural(input, now)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if text == "" {
+		return time.Time{}, "", fmt.Errorf("%w: reminder text is required", ErrInvalidInput)
+	}
+
+	return date, text, nil
+}
+
+// ListChatReminders возвращает ближайшие ещё не отправленные напоминания, созданные чатом.
+func (s *Service) ListChatReminders(chatID int64) ([]models.Notification, error) {
+	return s.storage.ListOwnedNotifications(chatID, chatListLimit)
+}
+
+// CancelChatReminder отменяет напоминание, если его создал этот чат и оно ещё не отправлено.
+// Иначе возвращается storage.ErrNotifyNotFound, чтобы не раскрывать чужие напоминания.
+func (s *Service) CancelChatReminder(chatID, notificationID int64) error {
+	return s.storage.CancelOwnedNotification(notificationID, chatID)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReminder(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 8, 8, 14, 20, 0, 0, loc)
+
+	date, text, err := parseReminder("tomorrow at 9 standup", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-08-09T09:00:00+03:00", date.Format(time.RFC3339))
+	assert.Equal(t, "standup", text)
+
+	date, text, err = parseReminder("in 15 minutes call Alice", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-08-08T14:35:00+03:00", date.Format(time.RFC3339))
+	assert.Equal(t, "call Alice", text)
+}
+
+func TestParseReminder_Invalid(t *testing.T) {
+	now := time.Now()
+
+	for _, input := range []string{"call mom", "buy milk in 5 minutes", "tomorrow"} {
+		_, _, err := parseReminder(input, now)
+		assert.ErrorIs(t, err, ErrInvalidInput, input)
+	}
+}
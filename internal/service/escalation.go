@@ -0,0 +1,146 @@
+package service
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/lib/datetime"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/storage"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// escalationSteps проверяет политику эскалации: интервал каждого шага и адреса его получателей.
+// Пустой канал получателя заменяется каналом по умолчанию.
+func (s *Service) escalationSteps(params models.NewNotification) ([]models.EscalationStep, error) {
+	if len(params.Escalation) > models.MaxEscalationSteps {
+		return nil, fmt.Errorf("%w: escalation allows at most %d steps", ErrInvalidInput, models.MaxEscalationSteps)
+	}
+
+	steps := make([]models.EscalationStep, 0, len(params.Escalation))
+	for i, step := range params.Escalation {
+		if _, err := escalationDelay(step); err != nil {
+			return nil, fmt.Errorf("%w: escalation step %d: %v", ErrInvalidInput, i+1, err)
+		}
+
+		targets := make([]models.EscalationTarget, 0, len(step.Recipients))
+		for _, target := range step.Recipients {
+			target.Channel = s.channels.Resolve(target.Channel)
+
+			err := s.channels.Validate(target.Channel, channel.Message{
+				RecipientID: target.RecipientID,
+				Address:     target.Address,
+				Text:        params.Text,
+				ParseMode:   params.Options.ParseMode,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%w: escalation step %d: %s: %v", ErrInvalidInput, i+1, target.Channel, err)
+			}
+
+			targets = append(targets, target)
+		}
+
+		steps = append(steps, models.EscalationStep{After: step.After, Recipients: targets})
+	}
+
+	return steps, nil
+}
+
+func escalationDelay(step models.EscalationStep) (time.Duration, error) {
+	return datetime.ParseDelay(step.After)
+}
+
+// startEscalation запускает отсчёт первого шага эскалации после доставки уведомления.
+func (s *Service) startEscalation(notification *models.Notification) error {
+	if len(notification.Escalation) == 0 || notification.EscalationLevel > 0 {
+		return nil
+	}
+
+	delay, err := escalationDelay(notification.Escalation[0])
+	if err != nil {
+		return err
+	}
+
+	return s.storage.StartEscalation(notification.ID, time.Now().Add(delay))
+}
+
+// ClaimDueEscalations забирает неподтверждённые уведомления, которым пора выполнить шаг эскалации.
+func (s *Service) ClaimDueEscalations() ([]models.Notification, error) {
+	return s.storage.ClaimDueEscalations(s.cfg.Escalation.BatchSize, s.cfg.Escalation.Lease)
+}
+
+// EscalateNotification выполняет очередной шаг эскалации: создаёт уведомления получателям шага
+// (или повторное — исходному получателю), которые отправляются обычным порядком, и назначает следующий шаг.
+func (s *Service) EscalateNotification(notification *models.Notification) error {
+	level := notification.EscalationLevel
+	if level >= len(notification.Escalation) {
+		return nil
+	}
+
+	step := notification.Escalation[level]
+
+	var nextAt *time.Time
+	if level+1 < len(notification.Escalation) {
+		delay, err := escalationDelay(notification.Escalation[level+1])
+		if err != nil {
+			return err
+		}
+		next := time.Now().Add(delay)
+		nextAt = &next
+	}
+
+	targets := make([]*models.Notification, 0, len(step.Recipients))
+	if len(step.Recipients) == 0 {
+		targets = append(targets, escalationCopy(notification, models.EscalationTarget{
+			RecipientID: notification.RecipientID,
+			Channel:     notification.Channel,
+			Address:     notification.Address,
+		}, notification.Fallback))
+	}
+	for _, target := range step.Recipients {
+		targets = append(targets, escalationCopy(notification, target, s.recipientFallback(target.RecipientID)))
+	}
+
+	err := s.storage.EscalateNotification(notification.ID, level, notification.Route().Channel, targets, nextAt)
+	if errors.Is(err, storage.ErrNotifyNotActive) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("service failed to escalate notification: %w", err)
+	}
+
+	return nil
+}
+
+// escalationCopy строит уведомление шага эскалации для получателя target с отправкой сразу.
+func escalationCopy(notification *models.Notification, target models.EscalationTarget, fallback []models.Route) *models.Notification {
+	parentID := notification.ID
+
+	return &models.Notification{
+		RecipientID:    target.RecipientID,
+		Channel:        target.Channel,
+		Address:        target.Address,
+		Fallback:       fallback,
+		Date:           time.Now(),
+		Timezone:       notification.Timezone,
+		Text:           notification.Text,
+		MessageOptions: notification.MessageOptions,
+		Metadata:       notification.Metadata,
+		AttachmentID:   notification.AttachmentID,
+		EscalationOf:   &parentID,
+	}
+}
+
+// acknowledgeEscalated подтверждает исходное уведомление, когда получатель отреагировал на уведомление эскалации.
+func (s *Service) acknowledgeEscalated(notification *models.Notification) error {
+	if notification.EscalationOf == nil {
+		return nil
+	}
+
+	err := s.storage.AcknowledgeNotification(*notification.EscalationOf, notification.Route().Channel)
+	if errors.Is(err, storage.ErrNotifyNotActive) {
+		return nil
+	}
+
+	return err
+}
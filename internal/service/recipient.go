@@ -0,0 +1,235 @@
+package service
+
+import (
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/storage"
+	"DelayedNotifier/internal/telegram/notifier"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxExternalIDLength совпадает с размером колонки recipients.external_id.
+	maxExternalIDLength = 128
+	// tokenBytes — длина токена привязки; в base64url он занимает 32 символа,
+	// что укладывается в 64 символа, которые Telegram допускает в параметре start.
+	tokenBytes = 24
+)
+
+// aliasPattern — допустимый alias: начинается с буквы, поэтому никогда не совпадает с числовым chat ID.
+var aliasPattern = regexp.MustCompile(`^[a-z][a-z0-9_.-]{0,63}$`)
+
+// ErrChatUnreachable означает, что чат Telegram отмечен недоступным: бот заблокирован или чат удалён.
+var ErrChatUnreachable = errors.New("telegram chat is unreachable")
+
+// CreateRecipient регистрирует получателя. Если чат не указан, выдаётся токен для привязки через /start.
+func (s *Service) CreateRecipient(params models.NewRecipient) (*models.Recipient, error) {
+	recipient := &models.Recipient{
+		Alias:      strings.ToLower(strings.TrimSpace(params.Alias)),
+		ExternalID: strings.TrimSpace(params.ExternalID),
+		Name:       strings.TrimSpace(params.Name),
+		ChatID:     params.ChatID,
+		Active:     true,
+	}
+
+	if err := validateRecipient(recipient); err != nil {
+		return nil, err
+	}
+
+	if recipient.ChatID == nil {
+		if err := s.issueToken(recipient); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.storage.CreateRecipient(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to create recipient: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListRecipients возвращает реестр получателей; деактивированные — только при includeInactive.
+func (s *Service) ListRecipients(includeInactive bool) ([]models.Recipient, error) {
+	return s.storage.ListRecipients(includeInactive)
+}
+
+// UpdateRecipient меняет поля получателя, указанные в update. Повторная активация
+// и RotateToken выдают новый токен привязки, если чат ещё не привязан или RotateToken задан явно.
+func (s *Service) UpdateRecipient(recipientID int64, update models.RecipientUpdate) (*models.Recipient, error) {
+	updated, err := s.storage.UpdateRecipient(recipientID, func(recipient *models.Recipient) error {
+		if update.Alias != nil {
+			recipient.Alias = strings.ToLower(strings.TrimSpace(*update.Alias))
+		}
+		if update.ExternalID != nil {
+			recipient.ExternalID = strings.TrimSpace(*update.ExternalID)
+		}
+		if update.Name != nil {
+			recipient.Name = strings.TrimSpace(*update.Name)
+		}
+		if update.ChatID != nil {
+			recipient.ChatID = update.ChatID
+			recipient.OnboardingToken = ""
+			recipient.TokenExpiresAt = nil
+		}
+
+		activated := update.Active != nil && *update.Active && !recipient.Active
+		if update.Active != nil {
+			recipient.Active = *update.Active
+		}
+		if recipient.Active {
+			recipient.DeactivatedReason = ""
+		}
+
+		if err := validateRecipient(recipient); err != nil {
+			return err
+		}
+
+		switch {
+		case !recipient.Active:
+			recipient.OnboardingToken = ""
+			recipient.TokenExpiresAt = nil
+		case update.RotateToken, activated && recipient.ChatID == nil:
+			return s.issueToken(recipient)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service failed to update recipient: %w", err)
+	}
+
+	// Получателя включили вручную: значит, его чат снова принимает сообщения.
+	if update.Active != nil && *update.Active && updated.ChatID != nil {
+		if err = s.storage.MarkChatReachable(*updated.ChatID); err != nil {
+			return nil, err
+		}
+	}
+
+	return updated, nil
+}
+
+// DeactivateRecipient выключает получателя: уведомления по его alias больше не принимаются.
+func (s *Service) DeactivateRecipient(recipientID int64) error {
+	return s.storage.DeactivateRecipient(recipientID)
+}
+
+// BindRecipient привязывает чат, в котором нажали /start с токеном, к получателю этого токена.
+// Неизвестный, истёкший или уже использованный токен — storage.ErrRecipientNotFound.
+func (s *Service) BindRecipient(token string, chatID int64) (*models.Recipient, error) {
+	return s.storage.BindRecipient(token, chatID)
+}
+
+// resolveRecipient подставляет chat ID получателя, заданного alias или external_id.
+func (s *Service) resolveRecipient(key string) (int64, error) {
+	recipient, err := s.storage.FindRecipient(key)
+	if errors.Is(err, storage.ErrRecipientNotFound) {
+		return 0, fmt.Errorf("%w: recipient %q not found", ErrInvalidInput, key)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if !recipient.Active {
+		return 0, fmt.Errorf("%w: recipient %q is deactivated", ErrInvalidInput, key)
+	}
+	if recipient.ChatID == nil {
+		return 0, fmt.Errorf("%w: recipient %q has not connected a chat yet", ErrInvalidInput, key)
+	}
+
+	return *recipient.ChatID, nil
+}
+
+func validateRecipient(recipient *models.Recipient) error {
+	if recipient.Alias == "" && recipient.ExternalID == "" {
+		return fmt.Errorf("%w: alias or external_id is required", ErrInvalidInput)
+	}
+
+	if recipient.Alias != "" && !aliasPattern.MatchString(recipient.Alias) {
+		return fmt.Errorf("%w: alias must start with a letter and contain up to 64 letters, digits, '_', '.' or '-'", ErrInvalidInput)
+	}
+
+	if utf8.RuneCountInString(recipient.ExternalID) > maxExternalIDLength {
+		return fmt.Errorf("%w: external_id must be at most %d characters", ErrInvalidInput, maxExternalIDLength)
+	}
+
+	return nil
+}
+
+// issueToken выдаёт получателю новый токен привязки чата со сроком действия из конфигурации.
+func (s *Service) issueToken(recipient *models.Recipient) error {
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return fmt.Errorf("failed to generate onboarding token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.cfg.Recipients.TokenTTL)
+
+	recipient.OnboardingToken = base64.RawURLEncoding.EncodeToString(raw)
+	recipient.TokenExpiresAt = &expiresAt
+
+	return nil
+}
+
+// recipientUnreachable запоминает, что чат Telegram недоступен навсегда: дальнейшие отправки в него
+// пропускаются, новые уведомления не принимаются, а получатели реестра с этим чатом выключаются.
+// Серия, которой некуда больше отправлять срабатывания, останавливается.
+func (s *Service) recipientUnreachable(notification *models.Notification, sendErr error) error {
+	if notification.Route().Channel != notifier.Name {
+		return nil
+	}
+
+	// Отправка пропущена из-за уже известной недоступности: чат отмечен раньше.
+	if !errors.Is(sendErr, ErrChatUnreachable) {
+		if err := s.storage.MarkChatUnreachable(notification.RecipientID, sendErr.Error()); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := notification.NextRoute(); ok || notification.SeriesID == nil {
+		return nil
+	}
+
+	return s.storage.DeactivateSeries(*notification.SeriesID)
+}
+
+// chatUnreachable возвращает ErrChatUnreachable с причиной, если чат отмечен недоступным.
+func (s *Service) chatUnreachable(chatID int64) error {
+	reason, unreachable, err := s.storage.ChatUnreachable(chatID)
+	if err != nil {
+		return err
+	}
+
+	if unreachable {
+		return fmt.Errorf("%w: %s", ErrChatUnreachable, reason)
+	}
+
+	return nil
+}
+
+// MarkChatReachable снимает с чата отметку недоступности, например когда из него снова пришла команда боту.
+func (s *Service) MarkChatReachable(chatID int64) error {
+	return s.storage.MarkChatReachable(chatID)
+}
+
+// migrateChat переносит уведомления, серии и получателей чата в новый чат после превращения группы в супергруппу.
+func (s *Service) migrateChat(notification *models.Notification, chatID int64) error {
+	err := s.storage.MigrateChat(notification.ID, notification.Route().Channel, notification.RecipientID, chatID)
+	if err != nil {
+		return err
+	}
+
+	if notification.OwnerChatID != nil && *notification.OwnerChatID == notification.RecipientID {
+		notification.OwnerChatID = &chatID
+	}
+	notification.RecipientID = chatID
+
+	return nil
+}
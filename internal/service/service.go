@@ -1,47 +1,355 @@
 package service
 
 import (
+	"DelayedNotifier/internal/blob"
+	"DelayedNotifier/internal/channel"
 	"DelayedNotifier/internal/config"
+	"DelayedNotifier/internal/lib/backoff"
+	"DelayedNotifier/internal/lib/datetime"
+	"DelayedNotifier/internal/lib/schedule"
 	"DelayedNotifier/internal/models"
 	"DelayedNotifier/internal/rabbitMQ/broker"
+	"DelayedNotifier/internal/storage"
 	"DelayedNotifier/internal/storage/postgres"
+	"DelayedNotifier/internal/telegram/markup"
 	"DelayedNotifier/internal/telegram/notifier"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 type Service struct {
 	storage  *postgres.Storage
 	broker   *broker.RabbitMQBroker
 	cfg      *config.Config
-	notifier *notifier.Notifier
+	channels *channel.Registry
+	blobs    blob.Store
 }
 
-func New(storage *postgres.Storage, broker *broker.RabbitMQBroker, cfg *config.Config, notifier *notifier.Notifier) *Service {
+func New(storage *postgres.Storage, broker *broker.RabbitMQBroker, cfg *config.Config, channels *channel.Registry, blobs blob.Store) *Service {
 	return &Service{
 		storage:  storage,
 		broker:   broker,
 		cfg:      cfg,
-		notifier: notifier,
+		channels: channels,
+		blobs:    blobs,
 	}
 }
 
-func (s *Service) CreateNotification(recipientID int64, dateStr, text string) (int64, error) {
-	notificationID, err := s.storage.CreateNotification(recipientID, dateStr, text)
+// ErrInvalidInput означает, что параметры уведомления некорректны (дата, расписание и т.п.).
+var ErrInvalidInput = errors.New("invalid input")
+
+func (s *Service) CreateNotification(params models.NewNotification) (*models.Notification, error) {
+	if params.Recipient != "" {
+		chatID, err := s.resolveRecipient(params.Recipient)
+		if err != nil {
+			return nil, err
+		}
+		params.RecipientID = chatID
+	}
+
+	params.Channel = s.channels.Resolve(params.Channel)
+
+	parseMode, err := markup.Normalize(params.Options.ParseMode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	params.Options.ParseMode = parseMode
+
+	// Разметка проверяется при создании для любого канала: иначе ошибка всплывёт только при отправке в Telegram.
+	if err = markup.Validate(parseMode, params.Text); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if len(params.Metadata) > 0 {
+		var object map[string]any
+		if err := json.Unmarshal(params.Metadata, &object); err != nil || object == nil {
+			return nil, fmt.Errorf("%w: metadata must be a JSON object", ErrInvalidInput)
+		}
+	}
+
+	if len(params.Fallback) == 0 {
+		params.Fallback = s.recipientFallback(params.RecipientID)
+	}
+
+	routes := append([]models.Route{{Channel: params.Channel, Address: params.Address}}, params.Fallback...)
+	for _, route := range routes {
+		if route.Channel == "" {
+			return nil, fmt.Errorf("%w: fallback channel is required", ErrInvalidInput)
+		}
+
+		if route.Channel == notifier.Name {
+			err := s.chatUnreachable(params.RecipientID)
+			if errors.Is(err, ErrChatUnreachable) {
+				return nil, fmt.Errorf("%w: %s: %v", ErrInvalidInput, route.Channel, err)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err := s.channels.Validate(route.Channel, channel.Message{
+			RecipientID: params.RecipientID,
+			Address:     route.Address,
+			Text:        params.Text,
+			ParseMode:   params.Options.ParseMode,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidInput, route.Channel, err)
+		}
+	}
+
+	if params.Escalation, err = s.escalationSteps(params); err != nil {
+		return nil, err
+	}
+
+	attachmentID, err := s.saveAttachment(params.Attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	notification, err := s.createNotification(params, attachmentID)
+	if err != nil && attachmentID != nil {
+		if discardErr := s.discardAttachment(*attachmentID); discardErr != nil {
+			err = fmt.Errorf("%w; failed to discard attachment: %w", err, discardErr)
+		}
+	}
+
+	return notification, err
+}
+
+// createNotification сохраняет разовое уведомление или серию с уже сохранённым вложением.
+func (s *Service) createNotification(params models.NewNotification, attachmentID *int64) (*models.Notification, error) {
+	if params.Schedule != nil {
+		return s.createSeries(params, attachmentID)
+	}
+
+	date, timezone, err := resolveDate(params, time.Now())
 	if err != nil {
-		return 0, fmt.Errorf("service failed to create notification: %w", err)
+		return nil, err
 	}
 
-	err = s.publishNotificationID(notificationID)
+	var ownerChatID *int64
+	if params.OwnerChatID != 0 {
+		ownerChatID = &params.OwnerChatID
+	}
+
+	notification, err := s.storage.CreateNotification(&models.Notification{
+		RecipientID:    params.RecipientID,
+		Channel:        params.Channel,
+		Address:        params.Address,
+		Fallback:       params.Fallback,
+		Date:           date,
+		Timezone:       timezone,
+		Text:           params.Text,
+		MessageOptions: params.Options,
+		Metadata:       params.Metadata,
+		AttachmentID:   attachmentID,
+		Escalation:     params.Escalation,
+		OwnerChatID:    ownerChatID,
+	})
 	if err != nil {
-		return notificationID, fmt.Errorf("service failed to publish notification ID: %w", err)
+		return nil, fmt.Errorf("service failed to create notification: %w", err)
 	}
 
-	return notificationID, nil
+	return notification, nil
 }
 
-func (s *Service) GetNotificationStatus(notificationID int64) (string, error) {
-	return s.storage.GetNotificationStatus(notificationID)
+func (s *Service) createSeries(params models.NewNotification, attachmentID *int64) (*models.Notification, error) {
+	loc, err := datetime.LoadLocation(params.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	startAt := time.Now().In(loc)
+	timezone := loc.String()
+	if params.Date != "" || params.Delay != "" || params.When != "" {
+		startAt, timezone, err = resolveDate(params, startAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	series := &models.Series{
+		RecipientID:    params.RecipientID,
+		Channel:        params.Channel,
+		Address:        params.Address,
+		Fallback:       params.Fallback,
+		Text:           params.Text,
+		MessageOptions: params.Options,
+		Metadata:       params.Metadata,
+		AttachmentID:   attachmentID,
+		Escalation:     params.Escalation,
+		StartAt:        startAt,
+		Timezone:       timezone,
+		MaxCount:       params.Schedule.Count,
+	}
+
+	switch {
+	case params.Schedule.Cron != "" && params.Schedule.RRule != "":
+		return nil, fmt.Errorf("%w: cron and rrule are mutually exclusive", ErrInvalidInput)
+	case params.Schedule.Cron != "":
+		series.Kind, series.Expression = models.ScheduleCron, params.Schedule.Cron
+	case params.Schedule.RRule != "":
+		series.Kind, series.Expression = models.ScheduleRRule, params.Schedule.RRule
+	default:
+		return nil, fmt.Errorf("%w: schedule requires cron or rrule", ErrInvalidInput)
+	}
+
+	if params.Schedule.Until != "" {
+		until, _, err := parseDate(params.Schedule.Until, timezone)
+		if err != nil {
+			return nil, err
+		}
+		series.Until = &until
+	}
+
+	sched, err := seriesSchedule(series)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	// COUNT и UNTIL из RRULE действуют так же, как одноимённые поля запроса.
+	if rule, ok := sched.(*schedule.RRule); ok {
+		if series.MaxCount == 0 {
+			series.MaxCount = rule.Count
+		}
+		if series.Until == nil {
+			series.Until = rule.Until
+		}
+	}
+
+	first := sched.Next(startAt.Add(-time.Nanosecond))
+	if first.IsZero() || series.Until != nil && first.After(*series.Until) {
+		return nil, fmt.Errorf("%w: schedule has no occurrences", ErrInvalidInput)
+	}
+
+	notification, err := s.storage.CreateSeries(series, first)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to create series: %w", err)
+	}
+
+	return notification, nil
+}
+
+// ScheduleNextOccurrence создаёт следующее срабатывание серии, к которой относится уведомление.
+// Если серия отменена, исчерпала число срабатываний или дату окончания, она деактивируется.
+func (s *Service) ScheduleNextOccurrence(notification *models.Notification) error {
+	if notification.SeriesID == nil {
+		return nil
+	}
+
+	series, err := s.storage.GetSeries(*notification.SeriesID)
+	if errors.Is(err, storage.ErrSeriesNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !series.Active {
+		return nil
+	}
+
+	if series.MaxCount > 0 && series.Occurrences >= series.MaxCount {
+		return s.storage.DeactivateSeries(series.ID)
+	}
+
+	sched, err := seriesSchedule(series)
+	if err != nil {
+		return err
+	}
+
+	// После простоя пропускаем срабатывания, которые уже в прошлом.
+	after := notification.Date
+	if now := time.Now(); now.After(after) {
+		after = now
+	}
+
+	next := sched.Next(after)
+	if next.IsZero() || series.Until != nil && next.After(*series.Until) {
+		return s.storage.DeactivateSeries(series.ID)
+	}
+
+	_, err = s.storage.CreateSeriesOccurrence(series.ID, next)
+	if errors.Is(err, storage.ErrNotifyExists) || errors.Is(err, storage.ErrSeriesNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *Service) CancelSeries(seriesID int64) error {
+	return s.storage.CancelSeries(seriesID)
+}
+
+// seriesSchedule строит расписание серии в её часовом поясе, чтобы "0 9 * * *"
+// означало 9:00 у получателя, а не на сервере.
+func seriesSchedule(series *models.Series) (schedule.Schedule, error) {
+	loc, err := datetime.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	switch series.Kind {
+	case models.ScheduleCron:
+		return schedule.ParseCron(series.Expression, loc)
+	case models.ScheduleRRule:
+		return schedule.ParseRRule(series.Expression, series.StartAt.In(loc))
+	default:
+		return nil, fmt.Errorf("unknown schedule kind %q", series.Kind)
+	}
+}
+
+// resolveDate вычисляет дату уведомления из абсолютной даты, задержки или фразы
+// на естественном языке. Задержка и фраза отсчитываются от now в часовом поясе запроса.
+func resolveDate(params models.NewNotification, now time.Time) (time.Time, string, error) {
+	set := 0
+	for _, v := range []string{params.Date, params.Delay, params.When} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return time.Time{}, "", fmt.Errorf("%w: date, delay and when are mutually exclusive", ErrInvalidInput)
+	}
+
+	if params.Date != "" {
+		return parseDate(params.Date, params.Timezone)
+	}
+
+	loc, err := datetime.LoadLocation(params.Timezone)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	switch {
+	case params.Delay != "":
+		delay, err := datetime.ParseDelay(params.Delay)
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		return now.Add(delay).In(loc), loc.String(), nil
+	case params.When != "":
+		date, err := datetime.ParseNatural(params.When, now.In(loc))
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		return date, loc.String(), nil
+	}
+
+	return time.Time{}, "", fmt.Errorf("%w: date, delay or when is required", ErrInvalidInput)
+}
+
+func parseDate(value, timezone string) (time.Time, string, error) {
+	date, zone, err := datetime.Parse(value, timezone)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	return date, zone, nil
 }
 
 func (s *Service) GetNotificationByID(notificationID int64) (*models.Notification, error) {
@@ -56,11 +364,383 @@ func (s *Service) DeleteNotification(notificationID int64) error {
 	return s.storage.DeleteNotification(notificationID)
 }
 
-func (s *Service) publishNotificationID(id int64) error {
-	message := []byte(strconv.FormatInt(id, 10))
-	return s.broker.Publish(s.cfg.Rabbit.QueueName, message)
+// ScheduleNotification ставит уведомление в очередь с задержкой до даты отправки
+// или до следующей повторной попытки.
+func (s *Service) ScheduleNotification(notification *models.Notification) error {
+	message := []byte(strconv.FormatInt(notification.ID, 10))
+	return s.broker.PublishDelayed(s.cfg.Rabbit.QueueName, message, notification.DueAt())
+}
+
+// DeferNotification заново публикует уведомление в очередь через delay, не меняя его в базе.
+// Так воркер откладывает сообщение, которое не смог обработать, пока база недоступна.
+func (s *Service) DeferNotification(notificationID int64, delay time.Duration) error {
+	message := []byte(strconv.FormatInt(notificationID, 10))
+	return s.broker.PublishDelayed(s.cfg.Rabbit.QueueName, message, time.Now().Add(delay))
+}
+
+// ClaimNotification помечает уведомление как обрабатываемое воркером.
+func (s *Service) ClaimNotification(notificationID int64) (bool, error) {
+	return s.storage.ClaimNotification(notificationID)
+}
+
+// FindStrandedNotifications возвращает уведомления, потерянные по пути к воркеру.
+func (s *Service) FindStrandedNotifications() ([]models.Notification, error) {
+	// Уведомления, срок которых наступит до следующего прохода, публикуются заранее.
+	return s.storage.FindStrandedNotifications(s.cfg.Reconciler.Grace, s.cfg.Scheduler.ClaimTimeout, s.cfg.Reconciler.Interval, s.cfg.Reconciler.BatchSize)
+}
+
+// RecoverNotification возвращает потерянное уведомление в pending и заново публикует его в очередь
+// с задержкой до срока отправки. Лишняя копия безопасна: воркер отправит уведомление один раз.
+func (s *Service) RecoverNotification(notification *models.Notification) error {
+	if notification.Status == models.StatusInFlight {
+		if err := s.storage.ReleaseNotification(notification.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.ScheduleNotification(notification); err != nil {
+		return err
+	}
+
+	return s.storage.MarkRequeued(notification.ID, notification.DueAt())
+}
+
+// ClaimOutboxMessages забирает сообщения outbox, ожидающие публикации.
+func (s *Service) ClaimOutboxMessages() ([]models.OutboxMessage, error) {
+	return s.storage.ClaimOutboxMessages(s.cfg.Outbox.BatchSize, s.cfg.Outbox.Lease)
 }
 
-func (s *Service) SendNotification(recipientID int64, text string) error {
-	return s.notifier.SendNotification(recipientID, text)
+// PublishOutboxMessage публикует сообщение outbox в брокер. При ошибке публикация
+// откладывается с экспоненциальной задержкой.
+func (s *Service) PublishOutboxMessage(message *models.OutboxMessage) error {
+	body := []byte(strconv.FormatInt(message.NotificationID, 10))
+
+	err := s.broker.PublishDelayed(s.cfg.Rabbit.QueueName, body, message.DeliverAt)
+	if err != nil {
+		nextAttemptAt := time.Now().Add(backoff.Exponential(message.Attempts, s.cfg.Outbox.RetryBase, s.cfg.Outbox.RetryMax))
+		if markErr := s.storage.MarkOutboxFailed(message.ID, err.Error(), nextAttemptAt); markErr != nil {
+			return fmt.Errorf("%w; %w", err, markErr)
+		}
+
+		return err
+	}
+
+	return s.storage.MarkOutboxDispatched(message.ID)
+}
+
+// DeadLetter отправляет сообщение в очередь недоставленных уведомлений.
+func (s *Service) DeadLetter(message []byte, reason string) error {
+	return s.broker.PublishDeadLetter(s.cfg.Rabbit.QueueName, message, reason)
+}
+
+func (s *Service) ListDeadLetters(limit int) ([]models.DeadLetter, error) {
+	return s.broker.ListDeadLetters(s.cfg.Rabbit.QueueName, limit)
+}
+
+// ReplayDeadLetter возвращает одно сообщение из очереди недоставленных в основную очередь.
+func (s *Service) ReplayDeadLetter(messageID string) error {
+	_, err := s.broker.ReplayDeadLetters(s.cfg.Rabbit.QueueName, messageID, s.resetDeadLetter)
+	return err
+}
+
+// ReplayDeadLetters возвращает все сообщения из очереди недоставленных в основную очередь.
+func (s *Service) ReplayDeadLetters() (int, error) {
+	return s.broker.ReplayDeadLetters(s.cfg.Rabbit.QueueName, "", s.resetDeadLetter)
+}
+
+func (s *Service) PurgeDeadLetters() (int, error) {
+	return s.broker.PurgeDeadLetters(s.cfg.Rabbit.QueueName)
+}
+
+// resetDeadLetter сбрасывает счётчик попыток уведомления, чтобы воркер снова его отправил.
+func (s *Service) resetDeadLetter(letter models.DeadLetter) error {
+	if letter.NotificationID == 0 {
+		return nil
+	}
+
+	err := s.storage.ResetNotification(letter.NotificationID)
+	if errors.Is(err, storage.ErrNotifyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	notification, err := s.storage.GetNotificationByID(letter.NotificationID)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.AddNotificationEvent(&models.NotificationEvent{
+		NotificationID: notification.ID,
+		Event:          models.EventReset,
+		Status:         models.StatusPending,
+		Channel:        notification.Route().Channel,
+		Error:          letter.Reason,
+	})
+}
+
+// SendNotification отправляет уведомление через его текущий канал: основной или резервный.
+// Возвращает идентификаторы отправленных сообщений, если канал их сообщает.
+func (s *Service) SendNotification(notification *models.Notification) ([]int64, error) {
+	route := notification.Route()
+
+	// В недоступный чат не отправляем: уведомление сразу переходит на резервный канал или завершается ошибкой.
+	if route.Channel == notifier.Name {
+		err := s.chatUnreachable(notification.RecipientID)
+		if errors.Is(err, ErrChatUnreachable) {
+			return nil, &channel.PermanentError{Err: err}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msg := channel.Message{
+		NotificationID:        notification.ID,
+		RecipientID:           notification.RecipientID,
+		Address:               route.Address,
+		Text:                  notification.Text,
+		ParseMode:             notification.ParseMode,
+		DisableWebPagePreview: notification.DisableWebPagePreview,
+		DisableNotification:   notification.DisableNotification,
+		SentParts:             notification.SentParts,
+		Date:                  notification.Date,
+		Metadata:              notification.Metadata,
+	}
+
+	if notification.AttachmentID != nil {
+		attachment, err := s.storage.GetAttachment(*notification.AttachmentID)
+		if err != nil {
+			return nil, err
+		}
+		msg.Attachment = s.channelAttachment(attachment)
+	}
+
+	return s.channels.SendParts(route.Channel, msg)
+}
+
+// GetNotificationEvents возвращает историю доставки уведомления.
+func (s *Service) GetNotificationEvents(notificationID int64) ([]models.NotificationEvent, error) {
+	return s.storage.GetNotificationEvents(notificationID)
+}
+
+// recipientFallback возвращает резервные каналы получателя из конфигурации.
+func (s *Service) recipientFallback(recipientID int64) []models.Route {
+	configured := s.cfg.Fallback[strconv.FormatInt(recipientID, 10)]
+
+	routes := make([]models.Route, 0, len(configured))
+	for _, route := range configured {
+		routes = append(routes, models.Route{Channel: route.Channel, Address: route.Address})
+	}
+
+	return routes
+}
+
+// ClaimDueNotifications забирает из БД уведомления, которые пора отправить.
+func (s *Service) ClaimDueNotifications() ([]models.Notification, error) {
+	return s.storage.ClaimDueNotifications(s.cfg.Scheduler.BatchSize, s.cfg.Scheduler.ClaimTimeout)
+}
+
+// DeliverNotification отправляет уведомление и сохраняет итоговый статус.
+// Неудачная попытка откладывается по политике повторов, пока не исчерпан лимит попыток;
+// затем уведомление переходит на следующий резервный канал, а если их нет — помечается failed.
+// Ограничение частоты откладывает отправку на время, указанное каналом, не расходуя попытку,
+// а недоступный получатель сразу исчерпывает попытки канала.
+func (s *Service) DeliverNotification(notification *models.Notification) error {
+	route := notification.Route()
+
+	messageIDs, sendErr := s.SendNotification(notification)
+	if err := s.recordMessages(notification, messageIDs); err != nil {
+		return err
+	}
+
+	// Группа стала супергруппой: chat ID меняется, и отправка сразу повторяется в новый чат.
+	var migrated *notifier.ChatMigratedError
+	if errors.As(sendErr, &migrated) {
+		if err := s.migrateChat(notification, migrated.MigrateTo); err != nil {
+			return fmt.Errorf("%w; %w", sendErr, err)
+		}
+
+		messageIDs, sendErr = s.SendNotification(notification)
+		if err := s.recordMessages(notification, messageIDs); err != nil {
+			return err
+		}
+	}
+
+	if sendErr == nil {
+		if err := s.UpdateNotificationStatus(notification.ID, models.StatusSent); err != nil {
+			return err
+		}
+
+		err := s.storage.AddNotificationEvent(&models.NotificationEvent{
+			NotificationID: notification.ID,
+			Event:          models.EventSent,
+			Status:         models.StatusSent,
+			Channel:        route.Channel,
+			Attempt:        notification.Attempts + 1,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = s.startEscalation(notification); err != nil {
+			return err
+		}
+
+		return s.ScheduleNextOccurrence(notification)
+	}
+
+	var limited *channel.RateLimitError
+	if errors.As(sendErr, &limited) {
+		return s.retryNotification(notification, notification.Attempts, time.Now().Add(limited.RetryAfter), sendErr)
+	}
+
+	attempts := notification.Attempts + 1
+
+	var permanent *channel.PermanentError
+	if errors.As(sendErr, &permanent) {
+		if err := s.recipientUnreachable(notification, sendErr); err != nil {
+			return fmt.Errorf("%w; %w", sendErr, err)
+		}
+
+		return s.failNotification(notification, attempts, sendErr)
+	}
+
+	if attempts >= s.cfg.Retry.MaxAttempts {
+		return s.failNotification(notification, attempts, sendErr)
+	}
+
+	delay := backoff.WithJitter(
+		backoff.Exponential(attempts-1, s.cfg.Retry.BaseDelay, s.cfg.Retry.MaxDelay),
+		s.cfg.Retry.Jitter,
+	)
+
+	return s.retryNotification(notification, attempts, time.Now().Add(delay), sendErr)
+}
+
+// recordMessages сохраняет идентификаторы доставленных частей и сдвигает счётчик отправленных частей.
+func (s *Service) recordMessages(notification *models.Notification, messageIDs []int64) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+
+	err := s.storage.AddNotificationMessages(notification.ID, notification.RouteIndex, notification.SentParts, messageIDs)
+	if err != nil {
+		return err
+	}
+	notification.SentParts += len(messageIDs)
+
+	return nil
+}
+
+// failNotification завершает попытки текущего канала: переключает уведомление на резервный канал,
+// а если их нет — помечает failed (или partial) и отправляет в DLQ.
+func (s *Service) failNotification(notification *models.Notification, attempts int, sendErr error) error {
+	if next, ok := notification.NextRoute(); ok {
+		return s.fallbackNotification(notification, next, attempts, sendErr)
+	}
+
+	// Если часть длинного сообщения уже дошла, получатель видит начало текста — это не полный отказ.
+	status := models.StatusFailed
+	if notification.SentParts > 0 {
+		status = models.StatusPartial
+	}
+
+	if err := s.storage.MarkNotificationFailed(notification.ID, status, attempts, sendErr.Error()); err != nil {
+		return err
+	}
+
+	err := s.storage.AddNotificationEvent(&models.NotificationEvent{
+		NotificationID: notification.ID,
+		Event:          models.EventFailed,
+		Status:         status,
+		Channel:        notification.Route().Channel,
+		Attempt:        attempts,
+		Error:          sendErr.Error(),
+	})
+	if err != nil {
+		return fmt.Errorf("%w; %w", sendErr, err)
+	}
+
+	if s.cfg.Scheduler.Mode != config.SchedulerPostgres {
+		reason := fmt.Sprintf("retries exhausted after %d attempts: %s", attempts, sendErr)
+		if err := s.DeadLetter([]byte(strconv.FormatInt(notification.ID, 10)), reason); err != nil {
+			return fmt.Errorf("%w; failed to dead-letter notification: %w", sendErr, err)
+		}
+	}
+
+	if err := s.ScheduleNextOccurrence(notification); err != nil {
+		return fmt.Errorf("%w; failed to schedule next occurrence: %w", sendErr, err)
+	}
+
+	return sendErr
+}
+
+// retryNotification откладывает следующую попытку до nextAttemptAt с уже израсходованными attempts.
+func (s *Service) retryNotification(notification *models.Notification, attempts int, nextAttemptAt time.Time, sendErr error) error {
+	err := s.storage.ScheduleNotificationRetry(notification.ID, attempts, sendErr.Error(), nextAttemptAt)
+	if err != nil {
+		return err
+	}
+
+	err = s.storage.AddNotificationEvent(&models.NotificationEvent{
+		NotificationID: notification.ID,
+		Event:          models.EventRetry,
+		Status:         models.StatusPending,
+		Channel:        notification.Route().Channel,
+		Attempt:        attempts,
+		Error:          sendErr.Error(),
+	})
+	if err != nil {
+		return fmt.Errorf("%w; %w", sendErr, err)
+	}
+
+	notification.Attempts = attempts
+	notification.NextAttemptAt = &nextAttemptAt
+	notification.Status = models.StatusPending
+
+	if s.cfg.Scheduler.Mode != config.SchedulerPostgres {
+		if err = s.ScheduleNotification(notification); err != nil {
+			return fmt.Errorf("%w; failed to schedule retry: %w", sendErr, err)
+		}
+	}
+
+	return sendErr
+}
+
+// fallbackNotification переключает уведомление на следующий резервный канал и сразу ставит его в очередь.
+func (s *Service) fallbackNotification(notification *models.Notification, next models.Route, attempts int, sendErr error) error {
+	reason := fmt.Sprintf("%s failed after %d attempts: %s", notification.Route().Channel, attempts, sendErr)
+
+	nextAttemptAt, err := s.storage.AdvanceNotificationRoute(notification.ID, notification.RouteIndex+1, reason)
+	if err != nil {
+		return fmt.Errorf("%w; %w", sendErr, err)
+	}
+
+	err = s.storage.AddNotificationEvent(&models.NotificationEvent{
+		NotificationID: notification.ID,
+		Event:          models.EventFallback,
+		Status:         models.StatusPending,
+		Channel:        next.Channel,
+		Attempt:        attempts,
+		Error:          reason,
+	})
+	if err != nil {
+		return fmt.Errorf("%w; %w", sendErr, err)
+	}
+
+	notification.RouteIndex++
+	notification.Attempts = 0
+	notification.SentParts = 0
+	notification.NextAttemptAt = &nextAttemptAt
+	notification.Status = models.StatusPending
+
+	if s.cfg.Scheduler.Mode != config.SchedulerPostgres {
+		if err = s.ScheduleNotification(notification); err != nil {
+			return fmt.Errorf("%w; failed to schedule fallback: %w", sendErr, err)
+		}
+	}
+
+	return sendErr
 }
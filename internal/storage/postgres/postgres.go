@@ -5,18 +5,23 @@ import (
 	"DelayedNotifier/internal/models"
 	"DelayedNotifier/internal/storage"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-redis/redis"
+	"github.com/lib/pq"
 	"log"
 	"time"
-
-	_ "github.com/lib/pq"
 )
 
+// notificationColumns — набор колонок, который читает scanNotification.
+const notificationColumns = `id, recipient_id, channel, address, fallback, route_index, date, timezone, text, parse_mode, disable_web_page_preview, disable_notification, metadata, status, attempts, next_attempt_at, last_error, series_id, attachment_id, sent_parts, acknowledged_at, escalation, escalation_level, escalate_at, escalation_of, owner_chat_id`
+
 type Storage struct {
 	db  *sql.DB
 	rdb *redis.Client
+	// useOutbox включает запись в outbox в одной транзакции с уведомлением (режим rabbit).
+	useOutbox bool
 }
 
 func InitDB(cfg *config.Config) (*Storage, error) {
@@ -57,78 +62,350 @@ func InitDB(cfg *config.Config) (*Storage, error) {
 	}
 
 	return &Storage{
-		db:  db,
-		rdb: rdb,
+		db:        db,
+		rdb:       rdb,
+		useOutbox: cfg.Scheduler.Mode != config.SchedulerPostgres,
 	}, nil
 }
 
-func (s *Storage) CreateNotification(recipientID int64, dateStr, text string) (int64, error) {
-	serverLocation, err := time.LoadLocation("Local")
+func (s *Storage) CreateNotification(notification *models.Notification) (*models.Notification, error) {
+	tx, err := s.db.Begin()
 	if err != nil {
-		return 0, fmt.Errorf("failed to load server location: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
 
-	date, err := time.ParseInLocation("2006-01-02 15:04:05", dateStr, serverLocation)
+	created, err := s.insertNotification(tx, notification)
 	if err != nil {
-		return 0, fmt.Errorf("invalid date format: %v", err)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit notification: %w", err)
 	}
 
-	dateUTC := date.UTC()
+	return created, nil
+}
 
-	var notificationId int64
-	err = s.db.QueryRow(
-		`INSERT INTO notifications (recipient_id, date, text) VALUES ($1, $2, $3) RETURNING id`,
-		recipientID, dateUTC, text,
-	).Scan(&notificationId)
+// CreateSeries сохраняет серию повторяющихся уведомлений вместе с её первым срабатыванием.
+func (s *Storage) CreateSeries(series *models.Series, firstDate time.Time) (*models.Notification, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var until sql.NullTime
+	if series.Until != nil {
+		until = sql.NullTime{Time: series.Until.UTC(), Valid: true}
+	}
 
+	err = tx.QueryRow(
+		`INSERT INTO series (recipient_id, channel, address, fallback, text, parse_mode, disable_web_page_preview,
+			disable_notification, metadata, attachment_id, escalation, kind, expression, start_at, timezone, until, max_count, occurrences)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, 1) RETURNING id`,
+		series.RecipientID, series.Channel, series.Address, routesJSON(series.Fallback), series.Text, series.ParseMode,
+		series.DisableWebPagePreview, series.DisableNotification, nullJSON(series.Metadata), series.AttachmentID,
+		escalationJSON(series.Escalation), series.Kind, series.Expression, series.StartAt.UTC(), series.Timezone, until, series.MaxCount,
+	).Scan(&series.ID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create notification: %v", err)
+		return nil, fmt.Errorf("failed to create series: %w", err)
 	}
 
-	err = s.rdb.Set(fmt.Sprintf("notification:%d", notificationId), "pending", 48*time.Hour).Err()
+	created, err := s.insertNotification(tx, &models.Notification{
+		RecipientID:    series.RecipientID,
+		Channel:        series.Channel,
+		Address:        series.Address,
+		Fallback:       series.Fallback,
+		Date:           firstDate,
+		Timezone:       series.Timezone,
+		Text:           series.Text,
+		MessageOptions: series.MessageOptions,
+		Metadata:       series.Metadata,
+		SeriesID:       &series.ID,
+		AttachmentID:   series.AttachmentID,
+		Escalation:     series.Escalation,
+	})
 	if err != nil {
-		log.Printf("Failed to set Redis key: %v", err)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit series: %w", err)
 	}
 
-	return notificationId, nil
+	return created, nil
 }
 
-func (s *Storage) GetNotificationStatus(notificationID int64) (string, error) {
-	status, err := s.rdb.Get(fmt.Sprintf("notification:%d", notificationID)).Result()
+func (s *Storage) GetSeries(seriesID int64) (*models.Series, error) {
+	var (
+		series       models.Series
+		until        sql.NullTime
+		fallback     []byte
+		metadata     []byte
+		attachmentID sql.NullInt64
+	)
+
+	err := s.db.QueryRow(
+		`SELECT id, recipient_id, channel, address, fallback, text, parse_mode, disable_web_page_preview, disable_notification, metadata, attachment_id, kind, expression, start_at, timezone, until, max_count, occurrences, active
+		FROM series WHERE id = $1`,
+		seriesID,
+	).Scan(
+		&series.ID,
+		&series.RecipientID,
+		&series.Channel,
+		&series.Address,
+		&fallback,
+		&series.Text,
+		&series.ParseMode,
+		&series.DisableWebPagePreview,
+		&series.DisableNotification,
+		&metadata,
+		&attachmentID,
+		&series.Kind,
+		&series.Expression,
+		&series.StartAt,
+		&series.Timezone,
+		&until,
+		&series.MaxCount,
+		&series.Occurrences,
+		&series.Active,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSeriesNotFound
+		}
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
 
-	if err == nil {
-		return status, nil
+	if until.Valid {
+		series.Until = &until.Time
 	}
+	if attachmentID.Valid {
+		series.AttachmentID = &attachmentID.Int64
+	}
+	// metadata — NULL, если её не передали; json.RawMessage не принимает NULL при сканировании.
+	series.Metadata = metadata
 
-	if !errors.Is(err, redis.Nil) {
-		log.Printf("Redis error: %v", err)
+	if series.Fallback, err = parseRoutes(fallback); err != nil {
+		return nil, err
 	}
 
-	err = s.db.QueryRow(
-		`SELECT status FROM notifications WHERE id = $1`,
-		notificationID,
-	).Scan(&status)
+	return &series, nil
+}
+
+// CreateSeriesOccurrence добавляет следующее срабатывание активной серии.
+// Повторный вызов для той же даты возвращает storage.ErrNotifyExists.
+func (s *Storage) CreateSeriesOccurrence(seriesID int64, date time.Time) (*models.Notification, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		recipientID  int64
+		channel      string
+		address      string
+		timezone     string
+		text         string
+		options      models.MessageOptions
+		metadata     []byte
+		fallback     []byte
+		attachmentID *int64
+		escalation   []byte
+	)
+
+	err = tx.QueryRow(
+		`SELECT recipient_id, channel, address, fallback, timezone, text, parse_mode, disable_web_page_preview,
+			disable_notification, metadata, attachment_id, escalation
+		FROM series WHERE id = $1 AND active FOR UPDATE`,
+		seriesID,
+	).Scan(&recipientID, &channel, &address, &fallback, &timezone, &text, &options.ParseMode,
+		&options.DisableWebPagePreview, &options.DisableNotification, &metadata, &attachmentID, &escalation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSeriesNotFound
+		}
+		return nil, fmt.Errorf("failed to lock series: %w", err)
+	}
+
+	routes, err := parseRoutes(fallback)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := parseEscalation(escalation)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.insertNotification(tx, &models.Notification{
+		RecipientID:    recipientID,
+		Channel:        channel,
+		Address:        address,
+		Fallback:       routes,
+		Date:           date,
+		Timezone:       timezone,
+		Text:           text,
+		MessageOptions: options,
+		Metadata:       metadata,
+		SeriesID:       &seriesID,
+		AttachmentID:   attachmentID,
+		Escalation:     steps,
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	_, err = tx.Exec(`UPDATE series SET occurrences = occurrences + 1 WHERE id = $1`, seriesID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get notification status: %v", err)
+		return nil, fmt.Errorf("failed to update series: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit series occurrence: %w", err)
 	}
 
-	return status, nil
+	return created, nil
 }
 
-func (s *Storage) GetNotificationByID(notificationID int64) (*models.Notification, error) {
-	var notification models.Notification
+func (s *Storage) DeactivateSeries(seriesID int64) error {
+	_, err := s.db.Exec(`UPDATE series SET active = FALSE WHERE id = $1`, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate series: %w", err)
+	}
 
-	err := s.db.QueryRow(
-		`SELECT id, recipient_id, date, text, status FROM notifications WHERE id = $1`,
+	return nil
+}
+
+// CancelSeries останавливает серию и удаляет её ещё не отправленные срабатывания.
+func (s *Storage) CancelSeries(seriesID int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.Exec(`UPDATE series SET active = FALSE WHERE id = $1`, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel series: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to cancel series: %w", err)
+	}
+
+	if affected == 0 {
+		return storage.ErrSeriesNotFound
+	}
+
+	rows, err := tx.Query(
+		`DELETE FROM notifications WHERE series_id = $1 AND status = $2 RETURNING id`,
+		seriesID, models.StatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to delete series notifications: %w", err)
+	}
+
+	var deleted []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan deleted notification: %w", err)
+		}
+		deleted = append(deleted, id)
+	}
+	_ = rows.Close()
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit series cancellation: %w", err)
+	}
+
+	return nil
+}
+
+// insertNotification добавляет уведомление в рамках транзакции и, в режиме rabbit,
+// кладёт в ту же транзакцию запись outbox для публикации в очередь.
+func (s *Storage) insertNotification(tx *sql.Tx, notification *models.Notification) (*models.Notification, error) {
+	dateUTC := notification.Date.UTC()
+
+	var notificationId int64
+	err := tx.QueryRow(
+		`INSERT INTO notifications (recipient_id, channel, address, fallback, date, timezone, text, parse_mode,
+			disable_web_page_preview, disable_notification, metadata, series_id, attachment_id, escalation, escalation_of,
+			owner_chat_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT DO NOTHING
+		RETURNING id`,
+		notification.RecipientID, notification.Channel, notification.Address, routesJSON(notification.Fallback), dateUTC,
+		notification.Timezone, notification.Text, notification.ParseMode, notification.DisableWebPagePreview,
+		notification.DisableNotification, nullJSON(notification.Metadata), notification.SeriesID, notification.AttachmentID,
+		escalationJSON(notification.Escalation), notification.EscalationOf, notification.OwnerChatID,
+	).Scan(&notificationId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrNotifyExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %v", err)
+	}
+
+	err = insertEvent(tx, &models.NotificationEvent{
+		NotificationID: notificationId,
+		Event:          models.EventCreated,
+		Status:         models.StatusPending,
+		Channel:        notification.Channel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.useOutbox {
+		_, err = tx.Exec(
+			`INSERT INTO outbox (notification_id, deliver_at) VALUES ($1, $2)`,
+			notificationId, dateUTC,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write outbox message: %v", err)
+		}
+	}
+
+	return &models.Notification{
+		ID:             notificationId,
+		RecipientID:    notification.RecipientID,
+		Channel:        notification.Channel,
+		Address:        notification.Address,
+		Fallback:       notification.Fallback,
+		Date:           dateUTC,
+		Timezone:       notification.Timezone,
+		Text:           notification.Text,
+		MessageOptions: notification.MessageOptions,
+		Metadata:       notification.Metadata,
+		Status:         models.StatusPending,
+		SeriesID:       notification.SeriesID,
+		AttachmentID:   notification.AttachmentID,
+		Escalation:     notification.Escalation,
+		EscalationOf:   notification.EscalationOf,
+		OwnerChatID:    notification.OwnerChatID,
+	}, nil
+}
+
+func (s *Storage) GetNotificationByID(notificationID int64) (*models.Notification, error) {
+	notification, err := scanNotification(s.db.QueryRow(
+		`SELECT `+notificationColumns+` FROM notifications WHERE id = $1`,
 		notificationID,
-	).Scan(
-		&notification.ID,
-		&notification.RecipientID,
-		&notification.Date,
-		&notification.Text,
-		&notification.Status,
-	)
+	))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -137,41 +414,1368 @@ func (s *Storage) GetNotificationByID(notificationID int64) (*models.Notificatio
 		return nil, fmt.Errorf("failed to get notification by ID: %w", err)
 	}
 
-	return &notification, nil
+	notification.MessageIDs, err = s.getNotificationMessageIDs(notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notification.Escalation) > 0 {
+		notification.Escalations, err = s.getEscalationIDs(notificationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return notification, nil
 }
 
-func (s *Storage) DeleteNotification(notificationID int64) error {
-	_, err := s.db.Exec(
-		`DELETE FROM notifications WHERE id = $1`,
-		notificationID)
+// getNotificationMessageIDs возвращает идентификаторы отправленных сообщений по всем каналам в порядке отправки.
+func (s *Storage) getNotificationMessageIDs(notificationID int64) ([]int64, error) {
+	rows, err := s.db.Query(
+		`SELECT message_id FROM notification_messages WHERE notification_id = $1 ORDER BY route_index, part`,
+		notificationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification messages: %w", err)
+	}
+	defer rows.Close()
 
+	var messageIDs []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan notification message: %w", err)
+		}
+		messageIDs = append(messageIDs, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get notification messages: %w", err)
+	}
+
+	return messageIDs, nil
+}
+
+// getEscalationIDs возвращает уведомления, созданные шагами эскалации, в порядке создания.
+func (s *Storage) getEscalationIDs(notificationID int64) ([]int64, error) {
+	rows, err := s.db.Query(
+		`SELECT id FROM notifications WHERE escalation_of = $1 ORDER BY id`,
+		notificationID,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to delete notification: %v", err)
+		return nil, fmt.Errorf("failed to get escalations: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan escalation: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get escalations: %w", err)
+	}
+
+	return ids, nil
+}
+
+// AddNotificationMessages сохраняет идентификаторы частей, отправленных через канал routeIndex,
+// начиная с части firstPart, и увеличивает счётчик sent_parts, чтобы повторная попытка продолжила с недоставленной части.
+func (s *Storage) AddNotificationMessages(notificationID int64, routeIndex, firstPart int, messageIDs []int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
 
-	key := fmt.Sprintf("notification:%d", notificationID)
-	err = s.rdb.Del(key).Err()
+	for i, messageID := range messageIDs {
+		_, err = tx.Exec(
+			`INSERT INTO notification_messages (notification_id, route_index, part, message_id)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (notification_id, route_index, part) DO UPDATE SET message_id = EXCLUDED.message_id, sent_at = now()`,
+			notificationID, routeIndex, firstPart+i, messageID)
+		if err != nil {
+			return fmt.Errorf("failed to save notification message: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(
+		`UPDATE notifications SET sent_parts = $1 WHERE id = $2`,
+		firstPart+len(messageIDs), notificationID)
 	if err != nil {
-		log.Printf("Failed to delete notification from Redis: %v", err)
+		return fmt.Errorf("failed to update sent parts: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit notification messages: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Storage) UpdateNotificationStatus(notificationID int64, status string) error {
+// ListOwnedNotifications возвращает до limit ещё не отправленных уведомлений, созданных чатом ownerChatID, по дате отправки.
+func (s *Storage) ListOwnedNotifications(ownerChatID int64, limit int) ([]models.Notification, error) {
+	rows, err := s.db.Query(
+		`SELECT `+notificationColumns+` FROM notifications
+		WHERE owner_chat_id = $1 AND status IN ($2, $3)
+		ORDER BY date
+		LIMIT $4`,
+		ownerChatID, models.StatusPending, models.StatusInFlight, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chat notification: %w", err)
+		}
+
+		notifications = append(notifications, *notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list chat notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CancelOwnedNotification удаляет ещё не отправленное уведомление, созданное чатом ownerChatID.
+// Чужое, уже отправленное или несуществующее уведомление — storage.ErrNotifyNotFound.
+func (s *Storage) CancelOwnedNotification(notificationID, ownerChatID int64) error {
+	res, err := s.db.Exec(
+		`DELETE FROM notifications WHERE id = $1 AND owner_chat_id = $2 AND status = $3`,
+		notificationID, ownerChatID, models.StatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to cancel notification: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to cancel notification: %w", err)
+	}
+
+	if affected == 0 {
+		return storage.ErrNotifyNotFound
+	}
+
+	return nil
+}
+
+// GetChatTimezone возвращает часовой пояс, выбранный чатом командой /tz, или пустую строку.
+func (s *Storage) GetChatTimezone(chatID int64) (string, error) {
+	var timezone string
+	err := s.db.QueryRow(`SELECT timezone FROM chat_settings WHERE chat_id = $1`, chatID).Scan(&timezone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get chat timezone: %w", err)
+	}
+
+	return timezone, nil
+}
+
+// SetChatTimezone сохраняет часовой пояс чата.
+func (s *Storage) SetChatTimezone(chatID int64, timezone string) error {
 	_, err := s.db.Exec(
-		`UPDATE notifications SET status = $1 WHERE id = $2`,
-		status, notificationID)
+		`INSERT INTO chat_settings (chat_id, timezone) VALUES ($1, $2)
+		ON CONFLICT (chat_id) DO UPDATE SET timezone = EXCLUDED.timezone, updated_at = now()`,
+		chatID, timezone)
+	if err != nil {
+		return fmt.Errorf("failed to set chat timezone: %w", err)
+	}
+
+	return nil
+}
+
+const recipientColumns = `id, alias, external_id, name, chat_id, active, deactivated_reason, onboarding_token, token_expires_at, created_at, updated_at`
+
+// CreateRecipient сохраняет получателя. Занятые alias или external_id — storage.ErrRecipientExists.
+func (s *Storage) CreateRecipient(recipient *models.Recipient) (*models.Recipient, error) {
+	row := s.db.QueryRow(
+		`INSERT INTO recipients (alias, external_id, name, chat_id, active, onboarding_token, token_expires_at)
+		VALUES (NULLIF($1, ''), NULLIF($2, ''), $3, $4, $5, NULLIF($6, ''), $7)
+		RETURNING `+recipientColumns,
+		recipient.Alias, recipient.ExternalID, recipient.Name, recipient.ChatID, recipient.Active,
+		recipient.OnboardingToken, recipient.TokenExpiresAt,
+	)
 
+	created, err := scanRecipient(row)
+	if isUniqueViolation(err) {
+		return nil, storage.ErrRecipientExists
+	}
 	if err != nil {
-		return fmt.Errorf("failed to update notification status: %v", err)
+		return nil, fmt.Errorf("failed to create recipient: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListRecipients возвращает получателей по порядку создания; неактивные — только при includeInactive.
+func (s *Storage) ListRecipients(includeInactive bool) ([]models.Recipient, error) {
+	rows, err := s.db.Query(
+		`SELECT `+recipientColumns+` FROM recipients
+		WHERE active OR $1
+		ORDER BY id`,
+		includeInactive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recipients: %w", err)
+	}
+	defer rows.Close()
+
+	recipients := make([]models.Recipient, 0)
+	for rows.Next() {
+		recipient, err := scanRecipient(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recipient: %w", err)
+		}
+
+		recipients = append(recipients, *recipient)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list recipients: %w", err)
+	}
+
+	return recipients, nil
+}
+
+func (s *Storage) GetRecipient(recipientID int64) (*models.Recipient, error) {
+	row := s.db.QueryRow(`SELECT `+recipientColumns+` FROM recipients WHERE id = $1`, recipientID)
+
+	recipient, err := scanRecipient(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrRecipientNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient: %w", err)
+	}
+
+	return recipient, nil
+}
+
+// FindRecipient ищет получателя по alias или external_id; совпадение alias важнее.
+func (s *Storage) FindRecipient(key string) (*models.Recipient, error) {
+	row := s.db.QueryRow(
+		`SELECT `+recipientColumns+` FROM recipients
+		WHERE alias = $1 OR external_id = $1
+		ORDER BY alias = $1 DESC NULLS LAST
+		LIMIT 1`,
+		key,
+	)
+
+	recipient, err := scanRecipient(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrRecipientNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recipient: %w", err)
+	}
+
+	return recipient, nil
+}
+
+// UpdateRecipient читает получателя под блокировкой, применяет к нему apply и сохраняет результат.
+// Ошибка apply возвращается как есть, изменения при этом не сохраняются.
+func (s *Storage) UpdateRecipient(recipientID int64, apply func(*models.Recipient) error) (*models.Recipient, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	recipient, err := scanRecipient(tx.QueryRow(
+		`SELECT `+recipientColumns+` FROM recipients WHERE id = $1 FOR UPDATE`, recipientID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrRecipientNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient: %w", err)
+	}
+
+	if err = apply(recipient); err != nil {
+		return nil, err
+	}
+
+	updated, err := scanRecipient(tx.QueryRow(
+		`UPDATE recipients
+		SET alias = NULLIF($2, ''), external_id = NULLIF($3, ''), name = $4, chat_id = $5, active = $6,
+			deactivated_reason = NULLIF($7, ''), onboarding_token = NULLIF($8, ''), token_expires_at = $9, updated_at = now()
+		WHERE id = $1
+		RETURNING `+recipientColumns,
+		recipientID, recipient.Alias, recipient.ExternalID, recipient.Name, recipient.ChatID, recipient.Active,
+		recipient.DeactivatedReason, recipient.OnboardingToken, recipient.TokenExpiresAt,
+	))
+	if isUniqueViolation(err) {
+		return nil, storage.ErrRecipientExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update recipient: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	s.rdb.Set(fmt.Sprintf("notification:%d", notificationID), status, 48*time.Hour)
+	return updated, nil
+}
+
+// DeactivateRecipient выключает получателя и отзывает его ссылку для привязки чата.
+func (s *Storage) DeactivateRecipient(recipientID int64) error {
+	res, err := s.db.Exec(
+		`UPDATE recipients
+		SET active = FALSE, deactivated_reason = NULL, onboarding_token = NULL, token_expires_at = NULL, updated_at = now()
+		WHERE id = $1`,
+		recipientID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate recipient: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to deactivate recipient: %w", err)
+	}
+	if affected == 0 {
+		return storage.ErrRecipientNotFound
+	}
 
 	return nil
 }
 
+// BindRecipient привязывает чат к активному получателю по действующему токену и гасит токен.
+// Неизвестный, истёкший или уже использованный токен — storage.ErrRecipientNotFound.
+func (s *Storage) BindRecipient(token string, chatID int64) (*models.Recipient, error) {
+	row := s.db.QueryRow(
+		`UPDATE recipients
+		SET chat_id = $2, onboarding_token = NULL, token_expires_at = NULL, updated_at = now()
+		WHERE onboarding_token = $1 AND active AND token_expires_at > now()
+		RETURNING `+recipientColumns,
+		token, chatID,
+	)
+
+	recipient, err := scanRecipient(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrRecipientNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind recipient: %w", err)
+	}
+
+	return recipient, nil
+}
+
+// MarkChatUnreachable запоминает, что чат Telegram недоступен навсегда (бот заблокирован, чат удалён),
+// и выключает привязанных к нему получателей реестра с той же причиной.
+func (s *Storage) MarkChatUnreachable(chatID int64, reason string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(
+		`INSERT INTO chat_settings (chat_id, unreachable_at, unreachable_reason) VALUES ($1, now(), $2)
+		ON CONFLICT (chat_id) DO UPDATE
+		SET unreachable_at = COALESCE(chat_settings.unreachable_at, now()), unreachable_reason = EXCLUDED.unreachable_reason, updated_at = now()`,
+		chatID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to mark chat unreachable: %w", err)
+	}
+
+	_, err = tx.Exec(
+		`UPDATE recipients
+		SET active = FALSE, deactivated_reason = $2, onboarding_token = NULL, token_expires_at = NULL, updated_at = now()
+		WHERE chat_id = $1 AND active`,
+		chatID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate chat recipients: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ChatUnreachable сообщает, отмечен ли чат недоступным, и возвращает причину.
+func (s *Storage) ChatUnreachable(chatID int64) (string, bool, error) {
+	var reason string
+	err := s.db.QueryRow(
+		`SELECT COALESCE(unreachable_reason, '') FROM chat_settings WHERE chat_id = $1 AND unreachable_at IS NOT NULL`,
+		chatID,
+	).Scan(&reason)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to check chat reachability: %w", err)
+	}
+
+	return reason, true, nil
+}
+
+// MarkChatReachable снимает с чата отметку недоступности.
+func (s *Storage) MarkChatReachable(chatID int64) error {
+	_, err := s.db.Exec(
+		`UPDATE chat_settings SET unreachable_at = NULL, unreachable_reason = NULL, updated_at = now()
+		WHERE chat_id = $1 AND unreachable_at IS NOT NULL`,
+		chatID)
+	if err != nil {
+		return fmt.Errorf("failed to mark chat reachable: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateChat переносит чат from в чат to: неотправленные уведомления, серии, получателей реестра
+// и настройки чата. В историю уведомления notificationID записывается событие переезда.
+func (s *Storage) MigrateChat(notificationID int64, channel string, from, to int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{
+			`UPDATE notifications SET recipient_id = $2 WHERE recipient_id = $1 AND status IN ($3, $4)`,
+			[]any{from, to, models.StatusPending, models.StatusInFlight},
+		},
+		{
+			`UPDATE notifications SET owner_chat_id = $2 WHERE owner_chat_id = $1 AND status IN ($3, $4)`,
+			[]any{from, to, models.StatusPending, models.StatusInFlight},
+		},
+		{`UPDATE series SET recipient_id = $2 WHERE recipient_id = $1 AND active`, []any{from, to}},
+		{`UPDATE recipients SET chat_id = $2, updated_at = now() WHERE chat_id = $1`, []any{from, to}},
+		{
+			`UPDATE chat_settings SET chat_id = $2, updated_at = now()
+			WHERE chat_id = $1 AND NOT EXISTS (SELECT 1 FROM chat_settings WHERE chat_id = $2)`,
+			[]any{from, to},
+		},
+	}
+	for _, statement := range statements {
+		if _, err = tx.Exec(statement.query, statement.args...); err != nil {
+			return fmt.Errorf("failed to migrate chat: %w", err)
+		}
+	}
+
+	err = insertEvent(tx, &models.NotificationEvent{
+		NotificationID: notificationID,
+		Event:          models.EventChatMigrated,
+		Status:         models.StatusInFlight,
+		Channel:        channel,
+		Error:          fmt.Sprintf("chat %d migrated to %d", from, to),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteNotification(notificationID int64) error {
+	_, err := s.db.Exec(
+		`DELETE FROM notifications WHERE id = $1`,
+		notificationID)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete notification: %v", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) UpdateNotificationStatus(notificationID int64, status string) error {
+	_, err := s.db.Exec(
+		`UPDATE notifications SET status = $1 WHERE id = $2`,
+		status, notificationID)
+
+	if err != nil {
+		return fmt.Errorf("failed to update notification status: %v", err)
+	}
+
+	return nil
+}
+
+// ClaimDueNotifications атомарно забирает до limit уведомлений, срок отправки которых наступил,
+// и переводит их в статус in_flight. Уведомления, захваченные раньше claimTimeout и так и не
+// обработанные, забираются повторно. Благодаря SKIP LOCKED несколько реплик не получат одну и ту же строку.
+func (s *Storage) ClaimDueNotifications(limit int, claimTimeout time.Duration) ([]models.Notification, error) {
+	rows, err := s.db.Query(
+		`UPDATE notifications SET status = $1, claimed_at = now()
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE (status = $2 AND COALESCE(next_attempt_at, date) <= now())
+			   OR (status = $1 AND claimed_at < now() - make_interval(secs => $3))
+			ORDER BY COALESCE(next_attempt_at, date)
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+notificationColumns,
+		models.StatusInFlight, models.StatusPending, claimTimeout.Seconds(), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan claimed notification: %w", err)
+		}
+
+		notifications = append(notifications, *notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim due notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// ScheduleNotificationRetry возвращает уведомление в pending после неудачной попытки
+// и откладывает следующую попытку до nextAttemptAt.
+func (s *Storage) ScheduleNotificationRetry(notificationID int64, attempts int, reason string, nextAttemptAt time.Time) error {
+	_, err := s.db.Exec(
+		`UPDATE notifications
+		SET status = $1, attempts = $2, last_error = $3, next_attempt_at = $4, claimed_at = NULL
+		WHERE id = $5`,
+		models.StatusPending, attempts, reason, nextAttemptAt.UTC(), notificationID)
+
+	if err != nil {
+		return fmt.Errorf("failed to schedule notification retry: %w", err)
+	}
+
+	return nil
+}
+
+// MarkNotificationFailed окончательно помечает уведомление как failed (или partial,
+// если часть длинного сообщения успела дойти) после исчерпания попыток.
+func (s *Storage) MarkNotificationFailed(notificationID int64, status string, attempts int, reason string) error {
+	_, err := s.db.Exec(
+		`UPDATE notifications SET status = $1, attempts = $2, last_error = $3, next_attempt_at = NULL WHERE id = $4`,
+		status, attempts, reason, notificationID)
+
+	if err != nil {
+		return fmt.Errorf("failed to mark notification failed: %w", err)
+	}
+
+	return nil
+}
+
+// AdvanceNotificationRoute переключает уведомление на резервный канал routeIndex после того,
+// как попытки текущего канала исчерпаны. Счётчик попыток обнуляется, отправка — сразу.
+func (s *Storage) AdvanceNotificationRoute(notificationID int64, routeIndex int, reason string) (time.Time, error) {
+	var nextAttemptAt time.Time
+	err := s.db.QueryRow(
+		`UPDATE notifications
+		SET status = $1, route_index = $2, attempts = 0, sent_parts = 0, last_error = $3, next_attempt_at = now(), claimed_at = NULL
+		WHERE id = $4
+		RETURNING next_attempt_at`,
+		models.StatusPending, routeIndex, reason, notificationID,
+	).Scan(&nextAttemptAt)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, storage.ErrNotifyNotFound
+		}
+		return time.Time{}, fmt.Errorf("failed to switch notification to fallback channel: %w", err)
+	}
+
+	return nextAttemptAt, nil
+}
+
+// AcknowledgeNotification отмечает доставленное уведомление выполненным по нажатию кнопки в канале channel.
+// Если уведомление уже отмечено или ещё не доставлено, возвращается storage.ErrNotifyNotActive.
+func (s *Storage) AcknowledgeNotification(notificationID int64, channel string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = acknowledge(tx, notificationID, models.StatusAcknowledged); err != nil {
+		return err
+	}
+
+	err = insertEvent(tx, &models.NotificationEvent{
+		NotificationID: notificationID,
+		Event:          models.EventAcknowledged,
+		Status:         models.StatusAcknowledged,
+		Channel:        channel,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit acknowledgement: %w", err)
+	}
+
+	return nil
+}
+
+// SnoozeNotification отмечает доставленное уведомление отложенным и в той же транзакции
+// создаёт его копию snoozed на новое время. Повторное нажатие возвращает storage.ErrNotifyNotActive.
+func (s *Storage) SnoozeNotification(notificationID int64, channel string, snoozed *models.Notification) (*models.Notification, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = acknowledge(tx, notificationID, models.StatusSnoozed); err != nil {
+		return nil, err
+	}
+
+	err = insertEvent(tx, &models.NotificationEvent{
+		NotificationID: notificationID,
+		Event:          models.EventSnoozed,
+		Status:         models.StatusSnoozed,
+		Channel:        channel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.insertNotification(tx, snoozed)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit snooze: %w", err)
+	}
+
+	return created, nil
+}
+
+// acknowledge переводит доставленное (sent или partial) и ещё не отмеченное уведомление в статус status.
+func acknowledge(tx *sql.Tx, notificationID int64, status string) error {
+	res, err := tx.Exec(
+		`UPDATE notifications SET status = $1, acknowledged_at = now(), escalate_at = NULL
+		WHERE id = $2 AND acknowledged_at IS NULL AND status IN ($3, $4)`,
+		status, notificationID, models.StatusSent, models.StatusPartial)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge notification: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge notification: %w", err)
+	}
+
+	if affected == 0 {
+		return storage.ErrNotifyNotActive
+	}
+
+	return nil
+}
+
+// StartEscalation запускает отсчёт эскалации доставленного уведомления: первый шаг назначается на escalateAt.
+func (s *Storage) StartEscalation(notificationID int64, escalateAt time.Time) error {
+	_, err := s.db.Exec(
+		`UPDATE notifications SET escalate_at = $1
+		WHERE id = $2 AND escalation_level = 0 AND acknowledged_at IS NULL`,
+		escalateAt.UTC(), notificationID)
+
+	if err != nil {
+		return fmt.Errorf("failed to start escalation: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDueEscalations забирает до limit неподтверждённых уведомлений, срок шага эскалации которых наступил,
+// и откладывает их escalate_at на lease: если процесс упадёт до выполнения шага, уведомление заберут снова.
+func (s *Storage) ClaimDueEscalations(limit int, lease time.Duration) ([]models.Notification, error) {
+	rows, err := s.db.Query(
+		`UPDATE notifications SET escalate_at = now() + make_interval(secs => $1)
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE escalate_at <= now() AND acknowledged_at IS NULL
+			ORDER BY escalate_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+notificationColumns,
+		lease.Seconds(), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due escalations: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan escalation: %w", err)
+		}
+
+		notifications = append(notifications, *notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim due escalations: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// EscalateNotification выполняет шаг эскалации level: в одной транзакции создаёт уведомления targets
+// и назначает следующий шаг на nextAt (nil — шагов больше нет). Если шаг уже выполнен или уведомление
+// подтверждено, возвращается storage.ErrNotifyNotActive.
+func (s *Storage) EscalateNotification(notificationID int64, level int, channel string, targets []*models.Notification, nextAt *time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var escalateAt sql.NullTime
+	if nextAt != nil {
+		escalateAt = sql.NullTime{Time: nextAt.UTC(), Valid: true}
+	}
+
+	var status string
+	err = tx.QueryRow(
+		`UPDATE notifications SET escalation_level = $1 + 1, escalate_at = $2
+		WHERE id = $3 AND escalation_level = $1 AND acknowledged_at IS NULL
+		RETURNING status`,
+		level, escalateAt, notificationID,
+	).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrNotifyNotActive
+	}
+	if err != nil {
+		return fmt.Errorf("failed to advance escalation: %w", err)
+	}
+
+	for _, target := range targets {
+		if _, err := s.insertNotification(tx, target); err != nil {
+			return err
+		}
+	}
+
+	err = insertEvent(tx, &models.NotificationEvent{
+		NotificationID: notificationID,
+		Event:          models.EventEscalated,
+		Status:         status,
+		Channel:        channel,
+		Attempt:        level + 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit escalation: %w", err)
+	}
+
+	return nil
+}
+
+// AddNotificationEvent добавляет запись в историю уведомления.
+func (s *Storage) AddNotificationEvent(event *models.NotificationEvent) error {
+	return insertEvent(s.db, event)
+}
+
+// GetNotificationEvents возвращает историю уведомления в хронологическом порядке.
+func (s *Storage) GetNotificationEvents(notificationID int64) ([]models.NotificationEvent, error) {
+	var exists bool
+	err := s.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM notifications WHERE id = $1)`, notificationID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification events: %w", err)
+	}
+
+	if !exists {
+		return nil, storage.ErrNotifyNotFound
+	}
+
+	rows, err := s.db.Query(
+		`SELECT id, notification_id, event, status, channel, attempt, error, created_at
+		FROM notification_events WHERE notification_id = $1 ORDER BY id`,
+		notificationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]models.NotificationEvent, 0)
+	for rows.Next() {
+		var (
+			event     models.NotificationEvent
+			lastError sql.NullString
+		)
+
+		err = rows.Scan(
+			&event.ID,
+			&event.NotificationID,
+			&event.Event,
+			&event.Status,
+			&event.Channel,
+			&event.Attempt,
+			&lastError,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification event: %w", err)
+		}
+
+		event.Error = lastError.String
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get notification events: %w", err)
+	}
+
+	return events, nil
+}
+
+// CreateAttachment сохраняет сведения о вложении; содержимое к этому моменту уже лежит в хранилище.
+func (s *Storage) CreateAttachment(attachment *models.Attachment) (*models.Attachment, error) {
+	var blobKey, url sql.NullString
+	if attachment.BlobKey != "" {
+		blobKey = sql.NullString{String: attachment.BlobKey, Valid: true}
+	}
+	if attachment.URL != "" {
+		url = sql.NullString{String: attachment.URL, Valid: true}
+	}
+
+	created := *attachment
+	err := s.db.QueryRow(
+		`INSERT INTO attachments (kind, file_name, content_type, size, blob_key, url)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		attachment.Kind, attachment.FileName, attachment.ContentType, attachment.Size, blobKey, url,
+	).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (s *Storage) GetAttachment(attachmentID int64) (*models.Attachment, error) {
+	attachment, err := scanAttachment(s.db.QueryRow(
+		`SELECT `+attachmentColumns+` FROM attachments WHERE id = $1`,
+		attachmentID,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// ExpiredAttachments возвращает вложения старше retention, которые больше не нужны:
+// на них не ссылаются ожидающие отправки уведомления и активные серии.
+func (s *Storage) ExpiredAttachments(retention time.Duration, limit int) ([]models.Attachment, error) {
+	rows, err := s.db.Query(
+		`SELECT `+attachmentColumns+` FROM attachments a
+		WHERE created_at < now() - make_interval(secs => $1)
+		  AND NOT EXISTS (SELECT 1 FROM notifications n WHERE n.attachment_id = a.id AND n.status IN ($2, $3))
+		  AND NOT EXISTS (SELECT 1 FROM series s WHERE s.attachment_id = a.id AND s.active)
+		ORDER BY id
+		LIMIT $4`,
+		retention.Seconds(), models.StatusPending, models.StatusInFlight, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []models.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, *attachment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find expired attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// DeleteAttachment удаляет запись о вложении; ссылки из уведомлений и серий обнуляются.
+func (s *Storage) DeleteAttachment(attachmentID int64) error {
+	_, err := s.db.Exec(`DELETE FROM attachments WHERE id = $1`, attachmentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return nil
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func insertEvent(db execer, event *models.NotificationEvent) error {
+	var reason sql.NullString
+	if event.Error != "" {
+		reason = sql.NullString{String: event.Error, Valid: true}
+	}
+
+	_, err := db.Exec(
+		`INSERT INTO notification_events (notification_id, event, status, channel, attempt, error)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		event.NotificationID, event.Event, event.Status, event.Channel, event.Attempt, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record notification event: %w", err)
+	}
+
+	return nil
+}
+
+// ResetNotification возвращает уведомление в pending с обнулённым счётчиком попыток.
+// Уже доставленные через основной канал части не отправляются повторно.
+func (s *Storage) ResetNotification(notificationID int64) error {
+	res, err := s.db.Exec(
+		`UPDATE notifications
+		SET status = $1, attempts = 0, route_index = 0, next_attempt_at = NULL, last_error = NULL, claimed_at = NULL,
+			sent_parts = (SELECT count(*) FROM notification_messages WHERE notification_id = $2 AND route_index = 0)
+		WHERE id = $2`,
+		models.StatusPending, notificationID)
+
+	if err != nil {
+		return fmt.Errorf("failed to reset notification: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to reset notification: %w", err)
+	}
+
+	if affected == 0 {
+		return storage.ErrNotifyNotFound
+	}
+
+	return nil
+}
+
+// ClaimNotification переводит уведомление из pending в in_flight. Возвращает false,
+// если уведомление уже обрабатывается или обработано — так повторная доставка
+// одного и того же сообщения не приводит к повторной отправке.
+func (s *Storage) ClaimNotification(notificationID int64) (bool, error) {
+	res, err := s.db.Exec(
+		`UPDATE notifications SET status = $1, claimed_at = now() WHERE id = $2 AND status = $3`,
+		models.StatusInFlight, notificationID, models.StatusPending)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to claim notification: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim notification: %w", err)
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// FindStrandedNotifications ищет уведомления, которые, судя по всему, потерялись по пути к воркеру:
+// просроченные более чем на grace pending-уведомления и зависшие дольше claimTimeout in_flight,
+// для которых нет неопубликованной записи в outbox. Кроме них возвращаются pending-уведомления,
+// срок которых наступит в ближайшие lookahead и которые для этого срока ещё не публиковались повторно
+// (см. MarkRequeued): если очередь очистили, они уйдут вовремя, а не через grace после срока.
+func (s *Storage) FindStrandedNotifications(grace, claimTimeout, lookahead time.Duration, limit int) ([]models.Notification, error) {
+	rows, err := s.db.Query(
+		`SELECT `+notificationColumns+` FROM notifications n
+		WHERE ((n.status = $1 AND COALESCE(n.next_attempt_at, n.date) < now() + make_interval(secs => $5)
+		        AND (COALESCE(n.next_attempt_at, n.date) < now() - make_interval(secs => $3)
+		          OR n.requeued_for IS DISTINCT FROM COALESCE(n.next_attempt_at, n.date)))
+		    OR (n.status = $2 AND n.claimed_at < now() - make_interval(secs => $4)))
+		  AND NOT EXISTS (
+			SELECT 1 FROM outbox o WHERE o.notification_id = n.id AND o.dispatched_at IS NULL
+		  )
+		ORDER BY COALESCE(n.next_attempt_at, n.date)
+		LIMIT $6`,
+		models.StatusPending, models.StatusInFlight, grace.Seconds(), claimTimeout.Seconds(), lookahead.Seconds(), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stranded notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stranded notification: %w", err)
+		}
+
+		notifications = append(notifications, *notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find stranded notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// MarkRequeued запоминает срок, к которому реконсилятор повторно опубликовал уведомление,
+// чтобы не публиковать его к тому же сроку на каждом проходе.
+func (s *Storage) MarkRequeued(notificationID int64, dueAt time.Time) error {
+	_, err := s.db.Exec(`UPDATE notifications SET requeued_for = $2 WHERE id = $1`, notificationID, dueAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification requeued: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseNotification возвращает зависшее in_flight-уведомление в pending.
+func (s *Storage) ReleaseNotification(notificationID int64) error {
+	_, err := s.db.Exec(
+		`UPDATE notifications SET status = $1, claimed_at = NULL WHERE id = $2 AND status = $3`,
+		models.StatusPending, notificationID, models.StatusInFlight)
+
+	if err != nil {
+		return fmt.Errorf("failed to release notification: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimOutboxMessages забирает до limit неотправленных сообщений outbox и откладывает их
+// повторную выдачу на lease, чтобы другие реплики не опубликовали их одновременно.
+func (s *Storage) ClaimOutboxMessages(limit int, lease time.Duration) ([]models.OutboxMessage, error) {
+	rows, err := s.db.Query(
+		`UPDATE outbox SET next_attempt_at = now() + make_interval(secs => $1)
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE dispatched_at IS NULL AND next_attempt_at <= now()
+			ORDER BY id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, notification_id, deliver_at, attempts`,
+		lease.Seconds(), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []models.OutboxMessage
+	for rows.Next() {
+		var message models.OutboxMessage
+		err = rows.Scan(&message.ID, &message.NotificationID, &message.DeliverAt, &message.Attempts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+func (s *Storage) MarkOutboxDispatched(messageID int64) error {
+	_, err := s.db.Exec(
+		`UPDATE outbox SET dispatched_at = now(), last_error = NULL WHERE id = $1`,
+		messageID)
+
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message dispatched: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) MarkOutboxFailed(messageID int64, reason string, nextAttemptAt time.Time) error {
+	_, err := s.db.Exec(
+		`UPDATE outbox SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2 WHERE id = $3`,
+		reason, nextAttemptAt.UTC(), messageID)
+
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+
+	return nil
+}
+
+// routesJSON сохраняет список резервных каналов как jsonb; пустой список — как NULL.
+func routesJSON(routes []models.Route) any {
+	if len(routes) == 0 {
+		return nil
+	}
+
+	raw, _ := json.Marshal(routes)
+
+	return string(raw)
+}
+
+func parseRoutes(raw []byte) ([]models.Route, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var routes []models.Route
+	if err := json.Unmarshal(raw, &routes); err != nil {
+		return nil, fmt.Errorf("failed to decode fallback routes: %w", err)
+	}
+
+	return routes, nil
+}
+
+func escalationJSON(steps []models.EscalationStep) any {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	raw, _ := json.Marshal(steps)
+
+	return string(raw)
+}
+
+func parseEscalation(raw []byte) ([]models.EscalationStep, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var steps []models.EscalationStep
+	if err := json.Unmarshal(raw, &steps); err != nil {
+		return nil, fmt.Errorf("failed to decode escalation policy: %w", err)
+	}
+
+	return steps, nil
+}
+
+// nullJSON передаёт пустой JSON как NULL, а непустой — строкой, чтобы PostgreSQL привёл её к jsonb.
+func nullJSON(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	return string(raw)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanNotification(row rowScanner) (*models.Notification, error) {
+	var (
+		notification  models.Notification
+		fallback      []byte
+		metadata      []byte
+		nextAttemptAt sql.NullTime
+		lastError     sql.NullString
+		seriesID      sql.NullInt64
+		attachmentID  sql.NullInt64
+		ackedAt       sql.NullTime
+		escalation    []byte
+		escalateAt    sql.NullTime
+		escalationOf  sql.NullInt64
+		ownerChatID   sql.NullInt64
+	)
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.RecipientID,
+		&notification.Channel,
+		&notification.Address,
+		&fallback,
+		&notification.RouteIndex,
+		&notification.Date,
+		&notification.Timezone,
+		&notification.Text,
+		&notification.ParseMode,
+		&notification.DisableWebPagePreview,
+		&notification.DisableNotification,
+		&metadata,
+		&notification.Status,
+		&notification.Attempts,
+		&nextAttemptAt,
+		&lastError,
+		&seriesID,
+		&attachmentID,
+		&notification.SentParts,
+		&ackedAt,
+		&escalation,
+		&notification.EscalationLevel,
+		&escalateAt,
+		&escalationOf,
+		&ownerChatID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if notification.Fallback, err = parseRoutes(fallback); err != nil {
+		return nil, err
+	}
+
+	// metadata — NULL, если её не передали; json.RawMessage не принимает NULL при сканировании.
+	notification.Metadata = metadata
+	if nextAttemptAt.Valid {
+		notification.NextAttemptAt = &nextAttemptAt.Time
+	}
+	notification.LastError = lastError.String
+	if seriesID.Valid {
+		notification.SeriesID = &seriesID.Int64
+	}
+	if attachmentID.Valid {
+		notification.AttachmentID = &attachmentID.Int64
+	}
+	if ackedAt.Valid {
+		notification.AcknowledgedAt = &ackedAt.Time
+	}
+	if escalateAt.Valid {
+		notification.EscalateAt = &escalateAt.Time
+	}
+	if escalationOf.Valid {
+		notification.EscalationOf = &escalationOf.Int64
+	}
+	if ownerChatID.Valid {
+		notification.OwnerChatID = &ownerChatID.Int64
+	}
+	if notification.Escalation, err = parseEscalation(escalation); err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+const attachmentColumns = `id, kind, file_name, content_type, size, blob_key, url, created_at`
+
+func scanAttachment(row rowScanner) (*models.Attachment, error) {
+	var (
+		attachment models.Attachment
+		blobKey    sql.NullString
+		url        sql.NullString
+	)
+
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.Kind,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&blobKey,
+		&url,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.BlobKey = blobKey.String
+	attachment.URL = url.String
+
+	return &attachment, nil
+}
+
+func scanRecipient(row rowScanner) (*models.Recipient, error) {
+	var (
+		recipient         models.Recipient
+		alias             sql.NullString
+		externalID        sql.NullString
+		chatID            sql.NullInt64
+		deactivatedReason sql.NullString
+		token             sql.NullString
+		tokenExpiresAt    sql.NullTime
+	)
+
+	err := row.Scan(
+		&recipient.ID,
+		&alias,
+		&externalID,
+		&recipient.Name,
+		&chatID,
+		&recipient.Active,
+		&deactivatedReason,
+		&token,
+		&tokenExpiresAt,
+		&recipient.CreatedAt,
+		&recipient.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient.Alias = alias.String
+	recipient.ExternalID = externalID.String
+	recipient.DeactivatedReason = deactivatedReason.String
+	recipient.OnboardingToken = token.String
+	if chatID.Valid {
+		recipient.ChatID = &chatID.Int64
+	}
+	if tokenExpiresAt.Valid {
+		recipient.TokenExpiresAt = &tokenExpiresAt.Time
+	}
+
+	return &recipient, nil
+}
+
+// isUniqueViolation сообщает, что запрос нарушил ограничение уникальности.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// Redis возвращает клиент Redis, к которому подключено хранилище; его использует ограничитель частоты отправки.
+func (s *Storage) Redis() *redis.Client {
+	return s.rdb
+}
+
 func (s *Storage) Close() error {
 	err := s.db.Close()
 	if err != nil {
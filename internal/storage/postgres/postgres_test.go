@@ -0,0 +1,286 @@
+package postgres
+
+import (
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/storage"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rowDriver — драйвер database/sql, который на запрос, содержащий query, возвращает одну заранее
+// заданную строку, а на остальные — пустой результат; изменяющие запросы затрагивают affected строк.
+// Выполненные запросы запоминаются в calls. Так запросы проверяются через настоящий database/sql без PostgreSQL.
+type rowDriver struct {
+	mu       sync.Mutex
+	query    string
+	row      []driver.Value
+	affected int64
+	calls    []call
+}
+
+type call struct {
+	query string
+	args  []driver.Value
+}
+
+func (d *rowDriver) Open(string) (driver.Conn, error) {
+	return &rowConn{driver: d}, nil
+}
+
+type rowConn struct {
+	driver *rowDriver
+}
+
+func (c *rowConn) Prepare(query string) (driver.Stmt, error) {
+	return &rowStmt{conn: c, query: query}, nil
+}
+
+func (c *rowConn) Close() error {
+	return nil
+}
+
+func (c *rowConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *rowConn) Commit() error {
+	return nil
+}
+
+func (c *rowConn) Rollback() error {
+	return nil
+}
+
+type rowStmt struct {
+	conn  *rowConn
+	query string
+}
+
+func (s *rowStmt) Close() error {
+	return nil
+}
+
+func (s *rowStmt) NumInput() int {
+	return -1
+}
+
+func (s *rowStmt) Exec(args []driver.Value) (driver.Result, error) {
+	d := s.conn.driver
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.calls = append(d.calls, call{query: s.query, args: args})
+
+	return driver.RowsAffected(d.affected), nil
+}
+
+func (s *rowStmt) Query(args []driver.Value) (driver.Rows, error) {
+	d := s.conn.driver
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.calls = append(d.calls, call{query: s.query, args: args})
+
+	if !strings.Contains(s.query, d.query) {
+		return &rows{read: true}, nil
+	}
+
+	return &rows{row: d.row}, nil
+}
+
+type rows struct {
+	row  []driver.Value
+	read bool
+}
+
+func (r *rows) Columns() []string {
+	// Пустому результату всё равно нужны колонки: их число сверяется с числом аргументов Scan.
+	columns := make([]string, max(len(r.row), 1))
+	for i := range columns {
+		columns[i] = "c" + strconv.Itoa(i)
+	}
+
+	return columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read = true
+	copy(dest, r.row)
+
+	return nil
+}
+
+var (
+	testDriver     = &rowDriver{}
+	registerDriver sync.Once
+)
+
+// storageReturning возвращает Storage, запросы которого, содержащие query, читают row.
+func storageReturning(t *testing.T, query string, row []driver.Value) *Storage {
+	t.Helper()
+
+	registerDriver.Do(func() {
+		sql.Register("postgres-row", testDriver)
+	})
+
+	testDriver.mu.Lock()
+	testDriver.query = query
+	testDriver.row = row
+	testDriver.affected = 0
+	testDriver.calls = nil
+	testDriver.mu.Unlock()
+
+	db, err := sql.Open("postgres-row", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &Storage{db: db}
+}
+
+// notificationRow — строка в порядке notificationColumns.
+func notificationRow(metadata driver.Value) []driver.Value {
+	return []driver.Value{
+		int64(1), int64(42), "telegram", "", nil, int64(0),
+		time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), "UTC", "Test", "", false, false,
+		metadata, "pending", int64(0), nil, nil, nil, nil, int64(0),
+		nil, nil, int64(0), nil, nil, nil,
+	}
+}
+
+func seriesRow(metadata driver.Value) []driver.Value {
+	return []driver.Value{
+		int64(3), int64(42), "telegram", "", nil, "Test", "", false, false,
+		metadata, nil, "interval", "1h", time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), "UTC",
+		nil, int64(0), int64(2), true,
+	}
+}
+
+func TestGetNotificationByID_NullMetadata(t *testing.T) {
+	s := storageReturning(t, "FROM notifications WHERE id", notificationRow(nil))
+
+	notification, err := s.GetNotificationByID(1)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), notification.ID)
+	assert.Nil(t, notification.Metadata)
+}
+
+func TestGetNotificationByID_Metadata(t *testing.T) {
+	s := storageReturning(t, "FROM notifications WHERE id", notificationRow([]byte(`{"order_id": 7}`)))
+
+	notification, err := s.GetNotificationByID(1)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"order_id": 7}`, string(notification.Metadata))
+}
+
+func TestGetSeries_NullMetadata(t *testing.T) {
+	s := storageReturning(t, "FROM series WHERE id", seriesRow(nil))
+
+	series, err := s.GetSeries(3)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), series.ID)
+	assert.Nil(t, series.Metadata)
+}
+
+func TestGetSeries_Metadata(t *testing.T) {
+	s := storageReturning(t, "FROM series WHERE id", seriesRow([]byte(`{"team": "ops"}`)))
+
+	series, err := s.GetSeries(3)
+	require.NoError(t, err)
+
+	assert.Equal(t, json.RawMessage(`{"team": "ops"}`), series.Metadata)
+}
+
+// lastCall возвращает последний выполненный запрос.
+func lastCall(t *testing.T) call {
+	t.Helper()
+
+	testDriver.mu.Lock()
+	defer testDriver.mu.Unlock()
+
+	require.NotEmpty(t, testDriver.calls)
+
+	return testDriver.calls[len(testDriver.calls)-1]
+}
+
+func TestCancelOwnedNotification_AnotherChat(t *testing.T) {
+	s := storageReturning(t, "", nil)
+
+	// Для чужого напоминания условие по owner_chat_id не совпадает, и DELETE ничего не удаляет.
+	err := s.CancelOwnedNotification(5, 2002)
+	assert.ErrorIs(t, err, storage.ErrNotifyNotFound)
+
+	c := lastCall(t)
+	assert.Contains(t, c.query, "owner_chat_id = $2")
+	assert.Equal(t, []driver.Value{int64(5), int64(2002), models.StatusPending}, c.args)
+}
+
+func TestListOwnedNotifications(t *testing.T) {
+	s := storageReturning(t, "WHERE owner_chat_id = $1", notificationRow(nil))
+
+	notifications, err := s.ListOwnedNotifications(1001, 20)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, int64(1), notifications[0].ID)
+
+	c := lastCall(t)
+	assert.Equal(t, int64(1001), c.args[0])
+	assert.Equal(t, int64(20), c.args[3])
+}
+
+func TestChatUnreachable(t *testing.T) {
+	s := storageReturning(t, "FROM chat_settings", []driver.Value{"Forbidden: bot was blocked by the user"})
+
+	reason, unreachable, err := s.ChatUnreachable(1001)
+	require.NoError(t, err)
+	assert.True(t, unreachable)
+	assert.Equal(t, "Forbidden: bot was blocked by the user", reason)
+	assert.Equal(t, []driver.Value{int64(1001)}, lastCall(t).args)
+}
+
+func TestChatUnreachable_Reachable(t *testing.T) {
+	s := storageReturning(t, "no such query", nil)
+
+	_, unreachable, err := s.ChatUnreachable(1001)
+	require.NoError(t, err)
+	assert.False(t, unreachable)
+}
+
+func TestMarkChatUnreachable(t *testing.T) {
+	s := storageReturning(t, "", nil)
+
+	require.NoError(t, s.MarkChatUnreachable(1001, "Forbidden: bot was blocked by the user"))
+
+	testDriver.mu.Lock()
+	calls := testDriver.calls
+	testDriver.mu.Unlock()
+
+	// Отметка чата и выключение получателей реестра — в одной транзакции.
+	require.Len(t, calls, 2)
+	assert.Contains(t, calls[0].query, "INSERT INTO chat_settings")
+	assert.Contains(t, calls[1].query, "UPDATE recipients")
+	for _, c := range calls {
+		assert.Equal(t, []driver.Value{int64(1001), "Forbidden: bot was blocked by the user"}, c.args)
+	}
+}
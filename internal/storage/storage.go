@@ -3,6 +3,11 @@ package storage
 import "errors"
 
 var (
-	ErrNotifyNotFound = errors.New("notification not found")
-	ErrNotifyExists   = errors.New("notification already exists")
+	ErrNotifyNotFound     = errors.New("notification not found")
+	ErrNotifyExists       = errors.New("notification already exists")
+	ErrSeriesNotFound     = errors.New("series not found")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrNotifyNotActive    = errors.New("notification is not awaiting acknowledgement")
+	ErrRecipientNotFound  = errors.New("recipient not found")
+	ErrRecipientExists    = errors.New("recipient with this alias or external id already exists")
 )
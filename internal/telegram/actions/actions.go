@@ -0,0 +1,105 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	tgbotapi "github.com/Syfaro/telegram-bot-api"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Виды действий кнопок под уведомлением.
+const (
+	KindAck    = "ack"
+	KindSnooze = "snooze"
+	// KindNoop — кнопка-подпись с результатом действия, нажатие на неё ничего не делает.
+	KindNoop = "noop"
+)
+
+var ErrInvalidData = errors.New("invalid callback data")
+
+// Action — действие, закодированное в callback_data кнопки: "ack:<id>", "snooze:<id>:<секунды>" или "noop".
+type Action struct {
+	Kind           string
+	NotificationID int64
+	Delay          time.Duration
+}
+
+// Data кодирует действие в callback_data. Telegram ограничивает её 64 байтами, которых с запасом хватает.
+func (a Action) Data() string {
+	switch a.Kind {
+	case KindAck:
+		return fmt.Sprintf("%s:%d", KindAck, a.NotificationID)
+	case KindSnooze:
+		return fmt.Sprintf("%s:%d:%d", KindSnooze, a.NotificationID, int64(a.Delay/time.Second))
+	default:
+		return KindNoop
+	}
+}
+
+// Parse разбирает callback_data, созданную Data.
+func Parse(data string) (Action, error) {
+	fields := strings.Split(data, ":")
+
+	switch {
+	case fields[0] == KindNoop && len(fields) == 1:
+		return Action{Kind: KindNoop}, nil
+	case fields[0] == KindAck && len(fields) == 2:
+		id, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || id <= 0 {
+			return Action{}, fmt.Errorf("%w: %q", ErrInvalidData, data)
+		}
+		return Action{Kind: KindAck, NotificationID: id}, nil
+	case fields[0] == KindSnooze && len(fields) == 3:
+		id, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || id <= 0 {
+			return Action{}, fmt.Errorf("%w: %q", ErrInvalidData, data)
+		}
+		seconds, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil || seconds <= 0 {
+			return Action{}, fmt.Errorf("%w: %q", ErrInvalidData, data)
+		}
+		return Action{Kind: KindSnooze, NotificationID: id, Delay: time.Duration(seconds) * time.Second}, nil
+	}
+
+	return Action{}, fmt.Errorf("%w: %q", ErrInvalidData, data)
+}
+
+// Keyboard строит кнопки под уведомлением: «Done» и «Snooze» на каждый интервал из snooze.
+func Keyboard(notificationID int64, snooze []time.Duration) tgbotapi.InlineKeyboardMarkup {
+	row := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("✅ Done", Action{Kind: KindAck, NotificationID: notificationID}.Data()),
+	)
+
+	for _, delay := range snooze {
+		action := Action{Kind: KindSnooze, NotificationID: notificationID, Delay: delay}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("⏰ Snooze "+FormatDelay(delay), action.Data()))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+// Result строит клавиатуру из одной кнопки-подписи, которая заменяет кнопки действий после нажатия.
+func Result(label string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(label, Action{Kind: KindNoop}.Data()),
+	))
+}
+
+// FormatDelay записывает интервал коротко: "10 min", "1 h", "1 h 30 min".
+func FormatDelay(delay time.Duration) string {
+	hours := int64(delay / time.Hour)
+	minutes := int64(delay % time.Hour / time.Minute)
+
+	switch {
+	case delay%time.Minute != 0 || delay <= 0:
+		return delay.String()
+	case hours == 0:
+		return fmt.Sprintf("%d min", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%d h", hours)
+	default:
+		return fmt.Sprintf("%d h %d min", hours, minutes)
+	}
+}
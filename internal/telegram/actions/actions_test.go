@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_RoundTrip(t *testing.T) {
+	for _, action := range []Action{
+		{Kind: KindAck, NotificationID: 42},
+		{Kind: KindSnooze, NotificationID: 42, Delay: 10 * time.Minute},
+		{Kind: KindSnooze, NotificationID: 7, Delay: time.Hour},
+		{Kind: KindNoop},
+	} {
+		parsed, err := Parse(action.Data())
+		require.NoError(t, err)
+		assert.Equal(t, action, parsed)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, data := range []string{"", "ack", "ack:x", "ack:-1", "snooze:1", "snooze:1:0", "snooze:1:x", "done:1", "noop:1"} {
+		_, err := Parse(data)
+		assert.ErrorIs(t, err, ErrInvalidData, data)
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	keyboard := Keyboard(42, []time.Duration{10 * time.Minute, time.Hour})
+
+	require.Len(t, keyboard.InlineKeyboard, 1)
+	row := keyboard.InlineKeyboard[0]
+	require.Len(t, row, 3)
+
+	assert.Equal(t, "✅ Done", row[0].Text)
+	assert.Equal(t, "ack:42", *row[0].CallbackData)
+	assert.Equal(t, "⏰ Snooze 10 min", row[1].Text)
+	assert.Equal(t, "snooze:42:600", *row[1].CallbackData)
+	assert.Equal(t, "⏰ Snooze 1 h", row[2].Text)
+	assert.Equal(t, "snooze:42:3600", *row[2].CallbackData)
+}
+
+func TestFormatDelay(t *testing.T) {
+	assert.Equal(t, "10 min", FormatDelay(10*time.Minute))
+	assert.Equal(t, "1 h", FormatDelay(time.Hour))
+	assert.Equal(t, "1 h 30 min", FormatDelay(90*time.Minute))
+	assert.Equal(t, "45s", FormatDelay(45*time.Second))
+}
@@ -0,0 +1,125 @@
+package markup
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// htmlTags — теги, которые поддерживает Bot API, и их обязательные атрибуты.
+var htmlTags = map[string][]string{
+	"b": nil, "strong": nil,
+	"i": nil, "em": nil,
+	"u": nil, "ins": nil,
+	"s": nil, "strike": nil, "del": nil,
+	"tg-spoiler": nil,
+	"span":       {"class"},
+	"a":          {"href"},
+	"code":       nil,
+	"pre":        nil,
+	"blockquote": nil,
+	"tg-emoji":   {"emoji-id"},
+}
+
+var (
+	htmlEntity = regexp.MustCompile(`^&(lt|gt|amp|quot|#[0-9]+|#x[0-9a-fA-F]+);`)
+	htmlAttr   = regexp.MustCompile(`^\s*([a-zA-Z-]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+)
+
+// validateHTML проверяет HTML-разметку по правилам Bot API: только поддерживаемые теги,
+// правильная вложенность, а символы <, > и & вне тегов записаны сущностями.
+func validateHTML(text string) error {
+	var stack []string
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '&':
+			if !htmlEntity.MatchString(text[i:]) {
+				return fmt.Errorf("HTML: '&' at position %d must be written as &amp;", i)
+			}
+
+		case '>':
+			return fmt.Errorf("HTML: '>' at position %d must be written as &gt;", i)
+
+		case '<':
+			end := strings.IndexByte(text[i:], '>')
+			if end < 0 {
+				return fmt.Errorf("HTML: '<' at position %d must be written as &lt;", i)
+			}
+
+			tag := text[i+1 : i+end]
+			var err error
+			if stack, err = applyTag(stack, tag, i); err != nil {
+				return err
+			}
+			i += end
+		}
+	}
+
+	if len(stack) > 0 {
+		return fmt.Errorf("HTML: tag <%s> is not closed", stack[len(stack)-1])
+	}
+
+	return nil
+}
+
+func applyTag(stack []string, tag string, pos int) ([]string, error) {
+	if strings.HasPrefix(tag, "/") {
+		name := strings.ToLower(strings.TrimSpace(tag[1:]))
+		if len(stack) == 0 || stack[len(stack)-1] != name {
+			return nil, fmt.Errorf("HTML: unexpected closing tag </%s> at position %d", name, pos)
+		}
+
+		return stack[:len(stack)-1], nil
+	}
+
+	nameEnd := strings.IndexAny(tag, " \t\n")
+	if nameEnd < 0 {
+		nameEnd = len(tag)
+	}
+	name := strings.ToLower(tag[:nameEnd])
+
+	required, ok := htmlTags[name]
+	if !ok {
+		return nil, fmt.Errorf("HTML: unsupported tag <%s> at position %d", name, pos)
+	}
+
+	attrs, err := parseAttrs(tag[nameEnd:])
+	if err != nil {
+		return nil, fmt.Errorf("HTML: %w in tag <%s> at position %d", err, name, pos)
+	}
+
+	for _, attr := range required {
+		if _, ok := attrs[attr]; !ok {
+			return nil, fmt.Errorf("HTML: tag <%s> at position %d requires attribute %s", name, pos, attr)
+		}
+	}
+
+	if name == "span" && attrs["class"] != "tg-spoiler" {
+		return nil, fmt.Errorf("HTML: <span> at position %d supports only class=\"tg-spoiler\"", pos)
+	}
+
+	for _, open := range stack {
+		if open == "code" || open == "pre" && name != "code" {
+			return nil, fmt.Errorf("HTML: tag <%s> at position %d cannot be nested in <%s>", name, pos, open)
+		}
+	}
+
+	return append(stack, name), nil
+}
+
+func parseAttrs(s string) (map[string]string, error) {
+	attrs := make(map[string]string)
+
+	for strings.TrimSpace(s) != "" {
+		m := htmlAttr.FindStringSubmatch(s)
+		if m == nil {
+			return nil, fmt.Errorf("malformed attributes %q", strings.TrimSpace(s))
+		}
+
+		attrs[strings.ToLower(m[1])] = m[2] + m[3] + m[4]
+		s = s[len(m[0]):]
+	}
+
+	return attrs, nil
+}
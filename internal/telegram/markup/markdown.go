@@ -0,0 +1,205 @@
+package markup
+
+import (
+	"fmt"
+	"strings"
+)
+
+// markdownReserved — символы, которые в MarkdownV2 вне разметки нужно экранировать обратным слэшем.
+const markdownReserved = "_*[]()~`>#+-=|{}.!"
+
+var markdownEntityNames = map[string]string{
+	"*":  "bold",
+	"_":  "italic",
+	"__": "underline",
+	"~":  "strikethrough",
+	"||": "spoiler",
+	"[":  "link",
+}
+
+// validateMarkdownV2 повторяет правила разбора MarkdownV2 из документации Bot API:
+// зарезервированные символы вне сущностей экранируются, сущности закрываются
+// в обратном порядке открытия, а внутри code/pre и URL ссылки экранируются только ` и \ или ) и \.
+func validateMarkdownV2(text string) error {
+	runes := []rune(text)
+
+	var stack []string
+	lineStart := true
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		atLineStart := lineStart
+		lineStart = r == '\n'
+
+		switch {
+		case r == '\\':
+			if i+1 >= len(runes) {
+				return fmt.Errorf("MarkdownV2: trailing '\\' at position %d", i)
+			}
+			if runes[i+1] < 1 || runes[i+1] > 126 {
+				return fmt.Errorf("MarkdownV2: character %q at position %d cannot be escaped", runes[i+1], i+1)
+			}
+			i++
+
+		case r == '`':
+			end, err := skipCode(runes, i)
+			if err != nil {
+				return err
+			}
+			i = end
+
+		case r == '*' || r == '~':
+			stack = toggleEntity(stack, string(r))
+
+		case r == '_':
+			marker := "_"
+			if i+1 < len(runes) && runes[i+1] == '_' && (top(stack) == "__" || !contains(stack, "__")) {
+				marker = "__"
+				i++
+			}
+			stack = toggleEntity(stack, marker)
+
+		case r == '|':
+			if i+1 >= len(runes) || runes[i+1] != '|' {
+				return reservedError(r, i)
+			}
+			i++
+			stack = toggleEntity(stack, "||")
+
+		case r == '!' && i+1 < len(runes) && runes[i+1] == '[':
+			// ![👍](tg://emoji?id=...) — пользовательский эмодзи, разбирается как ссылка.
+
+		case r == '[':
+			stack = append(stack, "[")
+
+		case r == ']':
+			if top(stack) != "[" {
+				return reservedError(r, i)
+			}
+			stack = stack[:len(stack)-1]
+
+			if i+1 >= len(runes) || runes[i+1] != '(' {
+				return fmt.Errorf("MarkdownV2: link text closed at position %d must be followed by (url)", i)
+			}
+			end, err := skipLinkURL(runes, i+1)
+			if err != nil {
+				return err
+			}
+			i = end
+
+		case r == '>' && atLineStart:
+			// Цитата в начале строки.
+
+		case strings.ContainsRune(markdownReserved, r):
+			return reservedError(r, i)
+		}
+
+		if err := checkOverlap(stack, i); err != nil {
+			return err
+		}
+	}
+
+	if len(stack) > 0 {
+		return fmt.Errorf("MarkdownV2: %s entity is not closed", markdownEntityNames[top(stack)])
+	}
+
+	return nil
+}
+
+// skipCode пропускает `code` или ```pre``` и возвращает позицию закрывающего символа.
+func skipCode(runes []rune, start int) (int, error) {
+	fence := 1
+	if start+2 < len(runes) && runes[start+1] == '`' && runes[start+2] == '`' {
+		fence = 3
+	}
+
+	for i := start + fence; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '`':
+			if fence == 1 {
+				return i, nil
+			}
+			if i+2 < len(runes) && runes[i+1] == '`' && runes[i+2] == '`' {
+				return i + 2, nil
+			}
+		}
+	}
+
+	if fence == 3 {
+		return 0, fmt.Errorf("MarkdownV2: pre block opened at position %d is not closed", start)
+	}
+
+	return 0, fmt.Errorf("MarkdownV2: code entity opened at position %d is not closed", start)
+}
+
+// skipLinkURL пропускает (url) ссылки и возвращает позицию закрывающей скобки.
+func skipLinkURL(runes []rune, start int) (int, error) {
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case ')':
+			if i == start+1 {
+				return 0, fmt.Errorf("MarkdownV2: empty link URL at position %d", start)
+			}
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("MarkdownV2: link URL opened at position %d is not closed", start)
+}
+
+// toggleEntity закрывает сущность, если она открыта последней, иначе открывает новую.
+func toggleEntity(stack []string, marker string) []string {
+	if top(stack) == marker {
+		return stack[:len(stack)-1]
+	}
+
+	return append(stack, marker)
+}
+
+// checkOverlap запрещает повторно открывать сущность, которая уже открыта глубже в стеке:
+// "*a _b* c_" Telegram не примет.
+func checkOverlap(stack []string, pos int) error {
+	if len(stack) < 2 {
+		return nil
+	}
+
+	last := stack[len(stack)-1]
+	for _, open := range stack[:len(stack)-1] {
+		if open == last && last != "[" {
+			return overlapError(last, stack[len(stack)-2], pos)
+		}
+	}
+
+	return nil
+}
+
+func overlapError(marker, open string, pos int) error {
+	return fmt.Errorf("MarkdownV2: %s entity at position %d overlaps unclosed %s entity",
+		markdownEntityNames[marker], pos, markdownEntityNames[open])
+}
+
+func reservedError(r rune, pos int) error {
+	return fmt.Errorf("MarkdownV2: character %q at position %d must be escaped with '\\'", r, pos)
+}
+
+func top(stack []string) string {
+	if len(stack) == 0 {
+		return ""
+	}
+
+	return stack[len(stack)-1]
+}
+
+func contains(stack []string, marker string) bool {
+	for _, s := range stack {
+		if s == marker {
+			return true
+		}
+	}
+
+	return false
+}
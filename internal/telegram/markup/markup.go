@@ -0,0 +1,53 @@
+package markup
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Режимы разметки текста уведомления в Telegram.
+const (
+	ModePlain      = "plain"
+	ModeMarkdownV2 = "MarkdownV2"
+	ModeHTML       = "HTML"
+)
+
+// Normalize приводит режим разметки к виду, который ожидает Telegram API.
+// Пустой режим означает обычный текст.
+func Normalize(mode string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", "plain", "text":
+		return ModePlain, nil
+	case "markdownv2":
+		return ModeMarkdownV2, nil
+	case "html":
+		return ModeHTML, nil
+	default:
+		return "", fmt.Errorf("unsupported parse_mode %q: expected plain, MarkdownV2 or HTML", mode)
+	}
+}
+
+// Validate проверяет, что Telegram примет text в режиме mode, чтобы некорректная разметка
+// отклонялась при создании уведомления, а не при отправке.
+func Validate(mode, text string) error {
+	switch mode {
+	case ModePlain, "":
+		return nil
+	case ModeMarkdownV2:
+		return validateMarkdownV2(text)
+	case ModeHTML:
+		return validateHTML(text)
+	default:
+		return fmt.Errorf("unsupported parse_mode %q", mode)
+	}
+}
+
+// TelegramParseMode возвращает значение параметра parse_mode для Bot API;
+// для обычного текста параметр не передаётся.
+func TelegramParseMode(mode string) string {
+	if mode == ModePlain {
+		return ""
+	}
+
+	return mode
+}
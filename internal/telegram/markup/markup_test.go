@@ -0,0 +1,104 @@
+package markup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	for input, want := range map[string]string{
+		"":           ModePlain,
+		"plain":      ModePlain,
+		"MarkdownV2": ModeMarkdownV2,
+		"markdownv2": ModeMarkdownV2,
+		"HTML":       ModeHTML,
+		"html":       ModeHTML,
+	} {
+		got, err := Normalize(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := Normalize("Markdown")
+	assert.Error(t, err)
+}
+
+func TestValidate_MarkdownV2(t *testing.T) {
+	valid := []string{
+		"plain text without reserved characters",
+		`*bold* _italic_ __underline__ ~strike~ ||spoiler||`,
+		`*bold _italic bold ~italic bold strike ||spoiler||~ __underline italic bold___ bold*`,
+		"Встреча в 10:00\\. Не опаздывай\\!",
+		"[ссылка](https://example.com/path?a=1&b=\\)2)",
+		"![👍](tg://emoji?id=5368324170671202286)",
+		"`inline code with * and _`",
+		"```go\nfmt.Println(\"*\")\n```",
+		"> цитата\n> вторая строка",
+		"\\_\\*\\[\\]\\(\\)\\~\\`\\>\\#\\+\\-\\=\\|\\{\\}\\.\\!",
+	}
+
+	for _, text := range valid {
+		assert.NoError(t, Validate(ModeMarkdownV2, text), text)
+	}
+
+	invalid := []string{
+		"Встреча в 10:00. Не опаздывай!",
+		"*bold",
+		"_italic",
+		"*bold _both* italic_",
+		"`code",
+		"```pre",
+		"[text]",
+		"[text](https://example.com",
+		"[text]()",
+		"a | b",
+		"a > b",
+		"trailing \\",
+		"1 + 1 = 2",
+	}
+
+	for _, text := range invalid {
+		assert.Error(t, Validate(ModeMarkdownV2, text), text)
+	}
+}
+
+func TestValidate_HTML(t *testing.T) {
+	valid := []string{
+		"plain text",
+		"<b>bold</b>, <strong>bold</strong>, <i>italic</i>, <u>underline</u>, <s>strike</s>",
+		`<span class="tg-spoiler">spoiler</span> <tg-spoiler>spoiler</tg-spoiler>`,
+		`<a href="https://example.com/?a=1&amp;b=2">link</a>`,
+		`<pre><code class="language-go">x &lt; y &amp;&amp; y &gt; z</code></pre>`,
+		"<b>bold <i>italic bold</i></b>",
+		"<blockquote expandable>long quote</blockquote>",
+		"&#128512; &#x1F600; &quot;quoted&quot;",
+	}
+
+	for _, text := range valid {
+		assert.NoError(t, Validate(ModeHTML, text), text)
+	}
+
+	invalid := []string{
+		"<b>bold",
+		"<b>bold <i>overlap</b></i>",
+		"<div>unsupported</div>",
+		"<a>no href</a>",
+		`<span class="red">not a spoiler</span>`,
+		"x < y",
+		"x > y",
+		"fish & chips",
+		"&nbsp;",
+		"</b>",
+		"<code><b>nested</b></code>",
+	}
+
+	for _, text := range invalid {
+		assert.Error(t, Validate(ModeHTML, text), text)
+	}
+}
+
+func TestValidate_Plain(t *testing.T) {
+	assert.NoError(t, Validate(ModePlain, "<b>*anything* goes_"))
+}
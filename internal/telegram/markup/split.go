@@ -0,0 +1,383 @@
+package markup
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// entity — сущность разметки, открытая в месте разреза: open повторяется в начале
+// следующей части, close дописывается в конец текущей.
+type entity struct {
+	open  string
+	close string
+}
+
+// atom — неделимый фрагмент исходного текста: символ, экранированная последовательность,
+// тег или inline-код. end — смещение конца фрагмента, stack — сущности, открытые после него.
+type atom struct {
+	end   int
+	stack []entity
+}
+
+// Split делит text на части не длиннее limit символов в UTF-16, как считает Telegram.
+// Разрез ищется по границе абзаца, затем строки, предложения и слова; сущности разметки,
+// открытые в месте разреза, закрываются в конце части и заново открываются в следующей.
+// Длина считается по исходному тексту с разметкой, поэтому части получаются с запасом.
+func Split(mode, text string, limit int) ([]string, error) {
+	if utf16Len(text) <= limit {
+		return []string{text}, nil
+	}
+
+	var atoms []atom
+	switch mode {
+	case ModeMarkdownV2:
+		atoms = markdownAtoms(text)
+	case ModeHTML:
+		atoms = htmlAtoms(text)
+	default:
+		atoms = plainAtoms(text)
+	}
+
+	// units[i] — длина text[:i] в UTF-16 для каждой границы символа.
+	units := make([]int, len(text)+1)
+	n := 0
+	for i, r := range text {
+		units[i] = n
+		n += utf16.RuneLen(r)
+	}
+	units[len(text)] = n
+
+	var (
+		parts []string
+		start int
+		stack []entity
+		next  int
+	)
+
+	for next < len(atoms) {
+		prefix := opening(stack)
+		prefixLen := utf16Len(prefix)
+
+		if prefixLen+units[len(text)]-units[start] <= limit {
+			parts = appendPart(parts, mode, prefix, text[start:], "")
+			break
+		}
+
+		best, bestScore := -1, -1
+		for k := next; k < len(atoms); k++ {
+			body := units[atoms[k].end] - units[start]
+			if prefixLen+body > limit {
+				break
+			}
+
+			size := prefixLen + body + utf16Len(closing(atoms[k].stack))
+			if size > limit {
+				continue
+			}
+
+			score := 0
+			// Слишком короткие части не нужны: граница абзаца в самом начале хуже границы слова в конце.
+			if size >= limit/2 {
+				score = 2*boundary(text[:atoms[k].end]) + 1
+				if len(atoms[k].stack) == 0 {
+					score++
+				}
+			}
+
+			if score >= bestScore {
+				best, bestScore = k, score
+			}
+		}
+
+		if best < 0 {
+			return nil, fmt.Errorf("text cannot be split into messages of %d characters: element at position %d is too long", limit, start)
+		}
+
+		end := atoms[best].end
+		parts = appendPart(parts, mode, prefix, text[start:end], closing(atoms[best].stack))
+
+		start, stack, next = end, atoms[best].stack, best+1
+	}
+
+	return parts, nil
+}
+
+// appendPart добавляет часть без пробелов на краях; часть из одних пробелов Telegram не примет.
+func appendPart(parts []string, mode, prefix, body, suffix string) []string {
+	body = strings.TrimLeftFunc(body, unicode.IsSpace)
+	body = trimRightSpace(mode, body)
+	if body == "" {
+		return parts
+	}
+
+	return append(parts, prefix+body+suffix)
+}
+
+// trimRightSpace убирает пробелы в конце части, не трогая экранированный в MarkdownV2 пробел.
+func trimRightSpace(mode, body string) string {
+	for body != "" {
+		r, size := utf8.DecodeLastRuneInString(body)
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		if mode == ModeMarkdownV2 {
+			slashes := len(body[:len(body)-size]) - len(strings.TrimRight(body[:len(body)-size], `\`))
+			if slashes%2 == 1 {
+				break
+			}
+		}
+
+		body = body[:len(body)-size]
+	}
+
+	return body
+}
+
+// boundary оценивает место разреза после prefix: 4 — конец абзаца, 3 — конец строки,
+// 2 — конец предложения, 1 — пробел, 0 — середина слова.
+func boundary(prefix string) int {
+	switch {
+	case strings.HasSuffix(prefix, "\n\n"):
+		return 4
+	case strings.HasSuffix(prefix, "\n"):
+		return 3
+	}
+
+	last, size := utf8.DecodeLastRuneInString(prefix)
+	if !unicode.IsSpace(last) {
+		return 0
+	}
+
+	before, _ := utf8.DecodeLastRuneInString(prefix[:len(prefix)-size])
+	if strings.ContainsRune(".!?…", before) {
+		return 2
+	}
+
+	return 1
+}
+
+func opening(stack []entity) string {
+	var b strings.Builder
+	for _, e := range stack {
+		b.WriteString(e.open)
+	}
+
+	return b.String()
+}
+
+func closing(stack []entity) string {
+	var b strings.Builder
+	for i := len(stack) - 1; i >= 0; i-- {
+		b.WriteString(stack[i].close)
+	}
+
+	return b.String()
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+
+	return n
+}
+
+// push возвращает новый стек, не изменяя stack: снимки стека хранятся в уже разобранных фрагментах.
+func push(stack []entity, e entity) []entity {
+	return append(stack[:len(stack):len(stack)], e)
+}
+
+func plainAtoms(text string) []atom {
+	atoms := make([]atom, 0, len(text))
+	for i, r := range text {
+		atoms = append(atoms, atom{end: i + utf8.RuneLen(r)})
+	}
+
+	return atoms
+}
+
+// markdownAtoms разбирает текст MarkdownV2, уже прошедший validateMarkdownV2.
+func markdownAtoms(text string) []atom {
+	var (
+		atoms []atom
+		stack []entity
+	)
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		end := i + size
+
+		switch {
+		case r == '\\' && end < len(text):
+			_, escaped := utf8.DecodeRuneInString(text[end:])
+			end += escaped
+
+		case strings.HasPrefix(text[i:], "```"):
+			if top := len(stack) - 1; top >= 0 && stack[top].close == "```" {
+				stack = stack[:top]
+				end = i + 3
+				break
+			}
+
+			closeAt := codeEnd(text, i+3, "```")
+			newline := strings.IndexByte(text[i+3:], '\n')
+			if newline < 0 || i+3+newline >= closeAt {
+				// Блок в одну строку не делится.
+				end = closeAt + 3
+				break
+			}
+
+			end = i + 3 + newline + 1
+			stack = push(stack, entity{open: text[i:end], close: "```"})
+
+		case len(stack) > 0 && stack[len(stack)-1].close == "```":
+			// Внутри pre значимы только экранирование и закрывающие ```.
+
+		case r == '`':
+			end = codeEnd(text, end, "`") + 1
+
+		case r == '*' || r == '~':
+			stack = toggle(stack, string(r))
+
+		case r == '_':
+			marker := "_"
+			if strings.HasPrefix(text[end:], "_") && (topMarker(stack) == "__" || !hasMarker(stack, "__")) {
+				marker = "__"
+				end++
+			}
+			stack = toggle(stack, marker)
+
+		case r == '|' && strings.HasPrefix(text[end:], "|"):
+			end++
+			stack = toggle(stack, "||")
+
+		case r == '!' && strings.HasPrefix(text[end:], "["):
+			end++
+			stack = push(stack, entity{open: "![", close: linkClose(text, end)})
+
+		case r == '[':
+			stack = push(stack, entity{open: "[", close: linkClose(text, end)})
+
+		case r == ']' && (topMarker(stack) == "[" || topMarker(stack) == "!["):
+			end = i + len(stack[len(stack)-1].close)
+			stack = stack[:len(stack)-1]
+		}
+
+		atoms = append(atoms, atom{end: end, stack: stack})
+		i = end
+	}
+
+	return atoms
+}
+
+// codeEnd возвращает позицию закрывающего fence, начиная поиск с from, с учётом экранирования.
+func codeEnd(text string, from int, fence string) int {
+	for i := from; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case strings.HasPrefix(text[i:], fence):
+			return i
+		}
+	}
+
+	return len(text) - len(fence)
+}
+
+// linkClose возвращает окончание ссылки "](url)", которое закрывает текст ссылки, начатый в from.
+func linkClose(text string, from int) string {
+	for i := from; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '`':
+			i = codeEnd(text, i+1, "`")
+		case ']':
+			for j := i + 2; j < len(text); j++ {
+				switch text[j] {
+				case '\\':
+					j++
+				case ')':
+					return text[i : j+1]
+				}
+			}
+			return text[i:]
+		}
+	}
+
+	return "]"
+}
+
+func toggle(stack []entity, marker string) []entity {
+	if topMarker(stack) == marker {
+		return stack[:len(stack)-1]
+	}
+
+	return push(stack, entity{open: marker, close: marker})
+}
+
+func topMarker(stack []entity) string {
+	if len(stack) == 0 {
+		return ""
+	}
+
+	return stack[len(stack)-1].open
+}
+
+func hasMarker(stack []entity, marker string) bool {
+	for _, e := range stack {
+		if e.open == marker {
+			return true
+		}
+	}
+
+	return false
+}
+
+// htmlAtoms разбирает HTML-текст, уже прошедший validateHTML.
+func htmlAtoms(text string) []atom {
+	var (
+		atoms []atom
+		stack []entity
+	)
+
+	for i := 0; i < len(text); {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		end := i + size
+
+		switch text[i] {
+		case '&':
+			if m := htmlEntity.FindString(text[i:]); m != "" {
+				end = i + len(m)
+			}
+
+		case '<':
+			closeAt := strings.IndexByte(text[i:], '>')
+			if closeAt < 0 {
+				break
+			}
+			end = i + closeAt + 1
+
+			tag := text[i+1 : end-1]
+			if strings.HasPrefix(tag, "/") {
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+				break
+			}
+
+			name := strings.ToLower(strings.Fields(tag + " ")[0])
+			stack = push(stack, entity{open: text[i:end], close: "</" + name + ">"})
+		}
+
+		atoms = append(atoms, atom{end: end, stack: stack})
+		i = end
+	}
+
+	return atoms
+}
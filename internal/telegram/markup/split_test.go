@@ -0,0 +1,97 @@
+package markup
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit_Short(t *testing.T) {
+	parts, err := Split(ModePlain, "короткое напоминание", 4096)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"короткое напоминание"}, parts)
+}
+
+func TestSplit_Paragraphs(t *testing.T) {
+	text := "The first paragraph is right here.\n\nSecond paragraph is a bit longer. It has two sentences.\n\nThird one."
+
+	parts, err := Split(ModePlain, text, 60)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"The first paragraph is right here.",
+		"Second paragraph is a bit longer. It has two sentences.",
+		"Third one.",
+	}, parts)
+}
+
+func TestSplit_Sentences(t *testing.T) {
+	text := "Первое предложение. Второе предложение! Третье предложение? Четвёртое."
+
+	parts, err := Split(ModePlain, text, 45)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Первое предложение. Второе предложение!",
+		"Третье предложение? Четвёртое.",
+	}, parts)
+}
+
+func TestSplit_UTF16(t *testing.T) {
+	text := strings.Repeat("🔔 ", 30)
+
+	parts, err := Split(ModePlain, text, 20)
+	require.NoError(t, err)
+	for _, part := range parts {
+		assert.LessOrEqual(t, len(utf16.Encode([]rune(part))), 20, part)
+	}
+	assert.Equal(t, strings.Repeat("🔔", 30), strings.ReplaceAll(strings.Join(parts, ""), " ", ""))
+}
+
+func TestSplit_MarkdownV2(t *testing.T) {
+	for _, text := range []string{
+		"*" + strings.Repeat("жирный текст\\. ", 10) + "*",
+		"_курсив *и жирный " + strings.Repeat("текст ", 20) + "*_",
+		"[" + strings.Repeat("текст ссылки ", 10) + "](https://example.com/a\\)b)",
+		"```go\n" + strings.Repeat("fmt.Println(\"*\")\n", 10) + "```",
+		strings.Repeat("`code` и \\*звёздочка\\* ", 10),
+	} {
+		require.NoError(t, Validate(ModeMarkdownV2, text), text)
+
+		parts, err := Split(ModeMarkdownV2, text, 80)
+		require.NoError(t, err, text)
+		assert.Greater(t, len(parts), 1, text)
+
+		for _, part := range parts {
+			assert.LessOrEqual(t, len(utf16.Encode([]rune(part))), 80, part)
+			assert.NoError(t, Validate(ModeMarkdownV2, part), part)
+		}
+	}
+}
+
+func TestSplit_HTML(t *testing.T) {
+	for _, text := range []string{
+		"<b>" + strings.Repeat("жирный &amp; текст. ", 10) + "</b>",
+		`<a href="https://example.com">` + strings.Repeat("<i>ссылка</i> ", 10) + "</a>",
+		"<pre><code class=\"language-go\">" + strings.Repeat("x := a &lt; b\n", 10) + "</code></pre>",
+	} {
+		require.NoError(t, Validate(ModeHTML, text), text)
+
+		parts, err := Split(ModeHTML, text, 100)
+		require.NoError(t, err, text)
+		assert.Greater(t, len(parts), 1, text)
+
+		for _, part := range parts {
+			assert.LessOrEqual(t, len(utf16.Encode([]rune(part))), 100, part)
+			assert.NoError(t, Validate(ModeHTML, part), part)
+		}
+	}
+}
+
+func TestSplit_TooLongElement(t *testing.T) {
+	text := "`" + strings.Repeat("x", 100) + "`"
+
+	_, err := Split(ModeMarkdownV2, text, 50)
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"DelayedNotifier/internal/channel"
+	"errors"
+	tgbotapi "github.com/Syfaro/telegram-bot-api"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChatMigratedError означает, что группа стала супергруппой и сообщения нужно отправлять в чат MigrateTo.
+type ChatMigratedError struct {
+	MigrateTo int64
+	Err       error
+}
+
+func (e *ChatMigratedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ChatMigratedError) Unwrap() error {
+	return e.Err
+}
+
+// retryAfterPattern находит задержку в описании ошибки 429: загрузка файлов в библиотеке
+// возвращает только текст ответа, без retry_after в параметрах.
+var retryAfterPattern = regexp.MustCompile(`retry after (\d+)`)
+
+// permanentErrors — описания ошибок Bot API, после которых отправка в чат не станет успешной.
+var permanentErrors = []string{
+	"Forbidden:",
+	"chat not found",
+	"user not found",
+	"group chat was deactivated",
+	"PEER_ID_INVALID",
+}
+
+// classify превращает ошибку Bot API в типизированную ошибку канала:
+// *channel.RateLimitError, *channel.PermanentError или *ChatMigratedError. Остальные ошибки возвращаются как есть.
+func classify(err error) error {
+	var apiErr tgbotapi.Error
+	if errors.As(err, &apiErr) {
+		if apiErr.RetryAfter > 0 {
+			return &channel.RateLimitError{RetryAfter: time.Duration(apiErr.RetryAfter) * time.Second, Err: err}
+		}
+		if apiErr.MigrateToChatID != 0 {
+			return &ChatMigratedError{MigrateTo: apiErr.MigrateToChatID, Err: err}
+		}
+	}
+
+	description := err.Error()
+
+	if match := retryAfterPattern.FindStringSubmatch(description); match != nil {
+		seconds, _ := strconv.Atoi(match[1])
+		return &channel.RateLimitError{RetryAfter: time.Duration(seconds) * time.Second, Err: err}
+	}
+
+	for _, permanent := range permanentErrors {
+		if strings.Contains(description, permanent) {
+			return &channel.PermanentError{Err: err}
+		}
+	}
+
+	return err
+}
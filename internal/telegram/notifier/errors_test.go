@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"DelayedNotifier/internal/channel"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/Syfaro/telegram-bot-api"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify_RateLimit(t *testing.T) {
+	err := classify(tgbotapi.Error{
+		Message:            "Too Many Requests: retry after 17",
+		ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 17},
+	})
+
+	var limited *channel.RateLimitError
+	require.ErrorAs(t, err, &limited)
+	assert.Equal(t, 17*time.Second, limited.RetryAfter)
+}
+
+func TestClassify_RateLimitUpload(t *testing.T) {
+	err := classify(errors.New("Too Many Requests: retry after 5"))
+
+	var limited *channel.RateLimitError
+	require.ErrorAs(t, err, &limited)
+	assert.Equal(t, 5*time.Second, limited.RetryAfter)
+}
+
+func TestClassify_Migrated(t *testing.T) {
+	err := classify(tgbotapi.Error{
+		Message:            "Bad Request: group chat was upgraded to a supergroup chat",
+		ResponseParameters: tgbotapi.ResponseParameters{MigrateToChatID: -1001234567890},
+	})
+
+	var migrated *ChatMigratedError
+	require.ErrorAs(t, err, &migrated)
+	assert.Equal(t, int64(-1001234567890), migrated.MigrateTo)
+}
+
+func TestClassify_Permanent(t *testing.T) {
+	for _, description := range []string{
+		"Forbidden: bot was blocked by the user",
+		"Forbidden: bot was kicked from the group chat",
+		"Bad Request: chat not found",
+	} {
+		err := fmt.Errorf("failed to send message to Telegram: %w", classify(tgbotapi.Error{Message: description}))
+
+		var permanent *channel.PermanentError
+		assert.ErrorAs(t, err, &permanent, description)
+	}
+}
+
+func TestClassify_Other(t *testing.T) {
+	original := tgbotapi.Error{Message: "Bad Request: can't parse entities"}
+	err := classify(original)
+
+	assert.Equal(t, original, err)
+}
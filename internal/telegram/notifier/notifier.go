@@ -1,16 +1,41 @@
 package notifier
 
 import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/telegram/actions"
+	"DelayedNotifier/internal/telegram/markup"
+	"errors"
 	"fmt"
 	tgbotapi "github.com/Syfaro/telegram-bot-api"
 	"log"
+	"time"
 )
 
+const Name = "telegram"
+
+// Ограничения Bot API на длину текста сообщения и подписи к файлу.
+const (
+	MaxMessageLength = 4096
+	MaxCaptionLength = 1024
+)
+
+// Limiter ограничивает частоту отправки: Wait возвращается, когда сообщение в чат chatID можно отправить.
+type Limiter interface {
+	Wait(chatID int64) error
+}
+
 type Notifier struct {
 	bot *tgbotapi.BotAPI
+	// limiter сглаживает всплески отправки под лимиты Bot API; nil — без ограничений.
+	limiter Limiter
+	// actions включает кнопки «Done» и «Snooze» под последним сообщением уведомления.
+	actions bool
+	snooze  []time.Duration
 }
 
-func New(token string) (*Notifier, error) {
+func New(token string, cfg config.Telegram, limiter Limiter) (*Notifier, error) {
 	bot, err := tgbotapi.NewBotAPI(token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Telegram bot: %w", err)
@@ -18,15 +43,179 @@ func New(token string) (*Notifier, error) {
 
 	log.Printf("Authorized on account %s", bot.Self.UserName)
 
-	return &Notifier{bot: bot}, nil
+	return &Notifier{
+		bot:     bot,
+		limiter: limiter,
+		actions: cfg.Updates != config.UpdatesOff,
+		snooze:  cfg.Snooze,
+	}, nil
+}
+
+// Bot возвращает клиент Bot API, через который принимаются нажатия кнопок.
+func (n *Notifier) Bot() *tgbotapi.BotAPI {
+	return n.bot
 }
 
-func (n *Notifier) SendNotification(recipientID int64, text string) error {
-	msg := tgbotapi.NewMessage(recipientID, text)
-	_, err := n.bot.Send(msg)
+// SendNotification отправляет сообщение; длинный текст делится на части, каждая — отдельным сообщением.
+func (n *Notifier) SendNotification(msg channel.Message) error {
+	_, err := n.SendParts(msg)
+	return err
+}
+
+// SendParts отправляет части по порядку, начиная с msg.SentParts, и возвращает
+// идентификаторы отправленных сообщений. Если оборвалась не первая часть, возвращается *channel.PartialError.
+// Ошибки Bot API классифицируются: лимит частоты, недоступный чат и переезд группы в супергруппу.
+// Кнопки действий прикрепляются к последнему сообщению, чтобы оказаться под всем текстом.
+func (n *Notifier) SendParts(msg channel.Message) ([]int64, error) {
+	parts, err := splitMessage(msg)
 	if err != nil {
-		return fmt.Errorf("failed to send message to Telegram: %w", err)
+		return nil, err
 	}
 
-	return nil
+	messageIDs := make([]int64, 0, len(parts))
+	for i := msg.SentParts; i < len(parts); i++ {
+		var keyboard *tgbotapi.InlineKeyboardMarkup
+		if n.actions && msg.NotificationID != 0 && i == len(parts)-1 {
+			buttons := actions.Keyboard(msg.NotificationID, n.snooze)
+			keyboard = &buttons
+		}
+
+		sent, err := n.send(msg, parts[i], keyboard)
+		if err != nil {
+			if i > 0 {
+				return messageIDs, &channel.PartialError{Sent: i, Total: len(parts), Err: err}
+			}
+			return messageIDs, err
+		}
+
+		messageIDs = append(messageIDs, int64(sent.MessageID))
+	}
+
+	return messageIDs, nil
+}
+
+// send дожидается места в лимитах частоты и отправляет часть; ошибки Bot API классифицируются.
+func (n *Notifier) send(msg channel.Message, p part, keyboard *tgbotapi.InlineKeyboardMarkup) (tgbotapi.Message, error) {
+	if n.limiter != nil {
+		err := n.limiter.Wait(msg.RecipientID)
+
+		var limited *channel.RateLimitError
+		if errors.As(err, &limited) {
+			return tgbotapi.Message{}, err
+		}
+		if err != nil {
+			// Без Redis лимиты не соблюсти, но доставка важнее: при превышении Telegram ответит 429.
+			log.Printf("Rate limiter unavailable, sending without it: %v", err)
+		}
+	}
+
+	sent, err := n.sendPart(msg, p, keyboard)
+	if err != nil {
+		return sent, fmt.Errorf("failed to send message to Telegram: %w", classify(err))
+	}
+
+	return sent, nil
+}
+
+// part — одно сообщение Telegram: часть текста или файл с подписью.
+type part struct {
+	text       string
+	attachment *channel.Attachment
+}
+
+// splitMessage раскладывает уведомление на сообщения. Вложение идёт первым: с текстом в подписи,
+// если он помещается в MaxCaptionLength, иначе без подписи, а текст — следующими сообщениями.
+func splitMessage(msg channel.Message) ([]part, error) {
+	if msg.Attachment != nil {
+		caption, err := markup.Split(msg.ParseMode, msg.Text, MaxCaptionLength)
+		if err == nil && len(caption) == 1 {
+			return []part{{text: msg.Text, attachment: msg.Attachment}}, nil
+		}
+	}
+
+	texts, err := markup.Split(msg.ParseMode, msg.Text, MaxMessageLength)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]part, 0, len(texts)+1)
+	if msg.Attachment != nil {
+		parts = append(parts, part{attachment: msg.Attachment})
+	}
+	for _, text := range texts {
+		parts = append(parts, part{text: text})
+	}
+
+	return parts, nil
+}
+
+// sendPart отправляет одно сообщение; keyboard, если задана, прикрепляется к нему кнопками.
+func (n *Notifier) sendPart(msg channel.Message, p part, keyboard *tgbotapi.InlineKeyboardMarkup) (tgbotapi.Message, error) {
+	parseMode := markup.TelegramParseMode(msg.ParseMode)
+
+	chat := tgbotapi.BaseChat{ChatID: msg.RecipientID, DisableNotification: msg.DisableNotification}
+	if keyboard != nil {
+		chat.ReplyMarkup = *keyboard
+	}
+
+	if p.attachment == nil {
+		return n.bot.Send(tgbotapi.MessageConfig{
+			BaseChat:              chat,
+			Text:                  p.text,
+			ParseMode:             parseMode,
+			DisableWebPagePreview: msg.DisableWebPagePreview,
+		})
+	}
+
+	file := tgbotapi.BaseFile{
+		BaseChat: chat,
+		MimeType: p.attachment.ContentType,
+	}
+
+	if p.attachment.URL != "" {
+		// Файл по ссылке Telegram скачивает сам.
+		file.FileID = p.attachment.URL
+		file.UseExisting = true
+	} else {
+		content, err := p.attachment.Open()
+		if err != nil {
+			return tgbotapi.Message{}, fmt.Errorf("failed to open attachment: %w", err)
+		}
+		defer func() {
+			_ = content.Close()
+		}()
+
+		size := p.attachment.Size
+		if size <= 0 {
+			size = -1
+		}
+		file.File = tgbotapi.FileReader{Name: p.attachment.FileName, Reader: content, Size: size}
+	}
+
+	if p.attachment.Kind == models.AttachmentPhoto {
+		return n.bot.Send(tgbotapi.PhotoConfig{BaseFile: file, Caption: p.text, ParseMode: parseMode})
+	}
+
+	return n.bot.Send(tgbotapi.DocumentConfig{BaseFile: file, Caption: p.text, ParseMode: parseMode})
+}
+
+func (n *Notifier) Name() string {
+	return Name
+}
+
+func (n *Notifier) Validate(msg channel.Message) error {
+	if msg.RecipientID == 0 {
+		return fmt.Errorf("recipient_id is required for Telegram")
+	}
+
+	if err := markup.Validate(msg.ParseMode, msg.Text); err != nil {
+		return err
+	}
+
+	_, err := markup.Split(msg.ParseMode, msg.Text, MaxMessageLength)
+	return err
+}
+
+func (n *Notifier) Send(msg channel.Message) error {
+	return n.SendNotification(msg)
 }
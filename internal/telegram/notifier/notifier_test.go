@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitMessage_Text(t *testing.T) {
+	parts, err := splitMessage(channel.Message{Text: strings.Repeat("Абзац текста. ", 400)})
+	require.NoError(t, err)
+	assert.Len(t, parts, 2)
+	for _, p := range parts {
+		assert.Nil(t, p.attachment)
+	}
+}
+
+func TestSplitMessage_AttachmentWithCaption(t *testing.T) {
+	attachment := &channel.Attachment{Kind: models.AttachmentDocument, FileName: "report.pdf"}
+
+	parts, err := splitMessage(channel.Message{Text: "Отчёт за неделю", Attachment: attachment})
+	require.NoError(t, err)
+	require.Len(t, parts, 1)
+	assert.Equal(t, "Отчёт за неделю", parts[0].text)
+	assert.Same(t, attachment, parts[0].attachment)
+}
+
+func TestSplitMessage_AttachmentWithLongText(t *testing.T) {
+	attachment := &channel.Attachment{Kind: models.AttachmentPhoto, URL: "https://example.com/chart.png"}
+	text := strings.Repeat("Длинная подпись. ", 100)
+
+	parts, err := splitMessage(channel.Message{Text: text, Attachment: attachment})
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Same(t, attachment, parts[0].attachment)
+	assert.Empty(t, parts[0].text)
+	assert.Equal(t, text, parts[1].text)
+}
+
+type limiterFunc func(chatID int64) error
+
+func (f limiterFunc) Wait(chatID int64) error {
+	return f(chatID)
+}
+
+func TestSendParts_RateLimited(t *testing.T) {
+	n := &Notifier{limiter: limiterFunc(func(chatID int64) error {
+		assert.Equal(t, int64(123), chatID)
+		return &channel.RateLimitError{RetryAfter: 3 * time.Second, Err: errors.New("limited")}
+	})}
+
+	messageIDs, err := n.SendParts(channel.Message{RecipientID: 123, Text: "Привет"})
+
+	var limited *channel.RateLimitError
+	require.ErrorAs(t, err, &limited)
+	assert.Equal(t, 3*time.Second, limited.RetryAfter)
+	assert.Empty(t, messageIDs)
+}
@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"DelayedNotifier/internal/channel"
+	"DelayedNotifier/internal/config"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	"strconv"
+	"time"
+)
+
+// ErrLimited означает, что место в корзинах не освободилось за MaxWait.
+var ErrLimited = errors.New("telegram rate limit: no capacity within max wait")
+
+const keyPrefix = "ratelimit:telegram:"
+
+// takeScript атомарно берёт по одному токену из каждой корзины KEYS или не берёт ни одного.
+// ARGV — пары «скорость пополнения в токенах за миллисекунду, ёмкость» для каждой корзины.
+// Возвращает 0, если токены взяты, иначе — через сколько миллисекунд стоит попробовать снова.
+// Время берётся у Redis, чтобы часы реплик не влияли на общие корзины.
+var takeScript = redis.NewScript(`
+redis.replicate_commands()
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local tokens = {}
+local wait = 0
+for i, key in ipairs(KEYS) do
+	local rate = tonumber(ARGV[2 * i - 1])
+	local burst = tonumber(ARGV[2 * i])
+	local bucket = redis.call('HMGET', key, 'tokens', 'ts')
+	local available = tonumber(bucket[1]) or burst
+	local ts = tonumber(bucket[2]) or now
+	available = math.min(burst, available + math.max(0, now - ts) * rate)
+	tokens[i] = available
+	if available < 1 then
+		wait = math.max(wait, math.ceil((1 - available) / rate))
+	end
+end
+
+if wait > 0 then
+	return wait
+end
+
+for i, key in ipairs(KEYS) do
+	local rate = tonumber(ARGV[2 * i - 1])
+	local burst = tonumber(ARGV[2 * i])
+	redis.call('HMSET', key, 'tokens', tokens[i] - 1, 'ts', now)
+	redis.call('PEXPIRE', key, math.ceil(burst / rate) + 1000)
+end
+
+return 0
+`)
+
+// Limiter — ограничитель частоты отправки в Telegram на корзинах токенов в Redis:
+// общая корзина бота, корзина чата и, для групп, корзина группы.
+type Limiter struct {
+	rdb *redis.Client
+	cfg config.RateLimit
+}
+
+func New(rdb *redis.Client, cfg config.RateLimit) *Limiter {
+	return &Limiter{
+		rdb: rdb,
+		cfg: cfg,
+	}
+}
+
+// Wait ждёт, пока во всех корзинах чата chatID появится токен, и забирает его.
+// Если ждать дольше MaxWait, возвращается *channel.RateLimitError с рекомендуемой задержкой.
+func (l *Limiter) Wait(chatID int64) error {
+	deadline := time.Now().Add(l.cfg.MaxWait)
+
+	keys, args := l.buckets(chatID)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for {
+		wait, err := takeScript.Run(l.rdb, keys, args...).Int64()
+		if err != nil {
+			return fmt.Errorf("failed to take rate limit token: %w", err)
+		}
+
+		if wait <= 0 {
+			return nil
+		}
+
+		delay := time.Duration(wait) * time.Millisecond
+		if time.Now().Add(delay).After(deadline) {
+			return &channel.RateLimitError{RetryAfter: delay, Err: ErrLimited}
+		}
+
+		time.Sleep(delay)
+	}
+}
+
+// buckets возвращает ключи корзин для чата и аргументы скрипта: скорость в токенах за миллисекунду и ёмкость.
+// Группы и каналы в Telegram имеют отрицательный chat ID и дополнительно ограничены в минуту.
+// Корзина с нулевым лимитом не ограничивает отправку.
+func (l *Limiter) buckets(chatID int64) ([]string, []any) {
+	chat := strconv.FormatInt(chatID, 10)
+
+	var (
+		keys []string
+		args []any
+	)
+	add := func(key string, limit int, period time.Duration) {
+		if limit > 0 {
+			keys = append(keys, keyPrefix+key)
+			args = append(args, perMillisecond(limit, period), limit)
+		}
+	}
+
+	add("global", l.cfg.Global, time.Second)
+	add("chat:"+chat, l.cfg.PerChat, time.Second)
+	if chatID < 0 {
+		add("group:"+chat, l.cfg.PerGroup, time.Minute)
+	}
+
+	return keys, args
+}
+
+func perMillisecond(limit int, period time.Duration) float64 {
+	return float64(limit) / float64(period.Milliseconds())
+}
@@ -0,0 +1,40 @@
+package ratelimit
+
+import (
+	"DelayedNotifier/internal/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuckets_PrivateChat(t *testing.T) {
+	l := New(nil, config.RateLimit{Global: 30, PerChat: 1, PerGroup: 20})
+
+	keys, args := l.buckets(123)
+
+	assert.Equal(t, []string{"ratelimit:telegram:global", "ratelimit:telegram:chat:123"}, keys)
+	assert.Equal(t, []any{0.03, 30, 0.001, 1}, args)
+}
+
+func TestBuckets_Group(t *testing.T) {
+	l := New(nil, config.RateLimit{Global: 30, PerChat: 1, PerGroup: 20})
+
+	keys, args := l.buckets(-100123)
+
+	assert.Equal(t, []string{
+		"ratelimit:telegram:global",
+		"ratelimit:telegram:chat:-100123",
+		"ratelimit:telegram:group:-100123",
+	}, keys)
+	assert.Len(t, args, 6)
+	assert.InDelta(t, 20.0/60000, args[4], 1e-12)
+	assert.Equal(t, 20, args[5])
+}
+
+func TestBuckets_Unlimited(t *testing.T) {
+	l := New(nil, config.RateLimit{Global: 30})
+
+	keys, _ := l.buckets(-100123)
+
+	assert.Equal(t, []string{"ratelimit:telegram:global"}, keys)
+}
@@ -0,0 +1,210 @@
+package receiver
+
+import (
+	"DelayedNotifier/internal/lib/datetime"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
+	"DelayedNotifier/internal/storage"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/Syfaro/telegram-bot-api"
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// listTextLength — сколько символов текста напоминания показывает /list.
+const listTextLength = 60
+
+const helpText = `I can remind you about things in this chat.
+
+/remind <when> <text> — set a reminder, e.g. /remind in 15 minutes call Alice or /remind tomorrow at 9 standup
+/list — your upcoming reminders
+/cancel <id> — cancel a reminder
+/tz <zone> — set your time zone, e.g. /tz Europe/Moscow`
+
+// handleCommand выполняет команду бота и отвечает в тот же чат.
+func (r *Receiver) handleCommand(message *tgbotapi.Message) {
+	// Раз чат пишет боту, бот снова может писать в чат.
+	if err := r.service.MarkChatReachable(message.Chat.ID); err != nil {
+		r.log.Error("Failed to mark chat reachable", "error", err, "chat_id", message.Chat.ID)
+	}
+
+	r.reply(message, r.command(message))
+}
+
+// command выполняет команду и возвращает текст ответа.
+// Напоминания создаются для чата, из которого пришла команда, и только он может их увидеть и отменить.
+func (r *Receiver) command(message *tgbotapi.Message) string {
+	chatID := message.Chat.ID
+	command := message.Command()
+	args := strings.TrimSpace(message.CommandArguments())
+
+	log := r.log.With(
+		slog.Int64("chat_id", chatID),
+		slog.String("command", command),
+	)
+
+	var (
+		text string
+		err  error
+	)
+
+	switch command {
+	case "start":
+		text, err = r.start(message, args)
+	case "help":
+		text = helpText
+	case "remind":
+		text, err = r.remind(chatID, args)
+	case "list":
+		text, err = r.list(chatID)
+	case "cancel":
+		text, err = r.cancel(chatID, args)
+	case "tz":
+		text, err = r.timezone(chatID, args)
+	default:
+		text = "Unknown command.\n\n" + helpText
+	}
+
+	var invalid *usageError
+	switch {
+	case errors.As(err, &invalid):
+		text = invalid.text
+	case errors.Is(err, service.ErrInvalidInput):
+		text = "Sorry, I couldn't understand that: " + strings.TrimPrefix(err.Error(), service.ErrInvalidInput.Error()+": ")
+	case err != nil:
+		log.Error("Failed to handle bot command", "error", err)
+		text = "Something went wrong, please try again"
+	}
+
+	return text
+}
+
+// usageError — ошибка в аргументах команды, текст которой показывается пользователю как есть.
+type usageError struct {
+	text string
+}
+
+func (e *usageError) Error() string {
+	return e.text
+}
+
+// start привязывает чат к получателю из реестра, если /start пришёл по ссылке с токеном, иначе показывает справку.
+func (r *Receiver) start(message *tgbotapi.Message, token string) (string, error) {
+	if token == "" {
+		return helpText, nil
+	}
+
+	recipient, err := r.service.BindRecipient(token, message.Chat.ID)
+	if errors.Is(err, storage.ErrRecipientNotFound) {
+		return "", &usageError{"This link is invalid or has expired. Please ask for a new one."}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	r.log.Info("Chat bound to recipient",
+		slog.Int64("chat_id", message.Chat.ID),
+		slog.Int64("recipient_id", recipient.ID),
+	)
+
+	return "Done! Notifications for you will now arrive in this chat.\n\n" + helpText, nil
+}
+
+func (r *Receiver) remind(chatID int64, args string) (string, error) {
+	if args == "" {
+		return "", &usageError{"Usage: /remind <when> <text>, e.g. /remind tomorrow at 9 standup"}
+	}
+
+	notification, err := r.service.CreateChatReminder(chatID, args)
+	if err != nil {
+		return "", err
+	}
+
+	r.log.Info("Reminder created from chat",
+		slog.Int64("chat_id", chatID),
+		slog.Int64("notification_id", notification.ID),
+	)
+
+	return fmt.Sprintf("Reminder #%d set for %s (%s)", notification.ID, formatDate(notification), notification.Timezone), nil
+}
+
+func (r *Receiver) list(chatID int64) (string, error) {
+	notifications, err := r.service.ListChatReminders(chatID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(notifications) == 0 {
+		return "You have no upcoming reminders. Set one with /remind <when> <text>", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("Upcoming reminders:\n")
+	for i := range notifications {
+		fmt.Fprintf(&b, "\n#%d — %s — %s", notifications[i].ID, formatDate(&notifications[i]), shorten(notifications[i].Text))
+	}
+
+	return b.String(), nil
+}
+
+func (r *Receiver) cancel(chatID int64, args string) (string, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(args, "#"), 10, 64)
+	if err != nil || id <= 0 {
+		return "", &usageError{"Usage: /cancel <id>, see /list for reminder IDs"}
+	}
+
+	err = r.service.CancelChatReminder(chatID, id)
+	if errors.Is(err, storage.ErrNotifyNotFound) {
+		return "", &usageError{fmt.Sprintf("Reminder #%d not found among your upcoming reminders", id)}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Reminder #%d cancelled", id), nil
+}
+
+func (r *Receiver) timezone(chatID int64, args string) (string, error) {
+	if args == "" {
+		timezone, err := r.service.ChatTimezone(chatID)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("Your time zone is %s. Change it with /tz <zone>, e.g. /tz Europe/Moscow", timezone), nil
+	}
+
+	timezone, err := r.service.SetChatTimezone(chatID, args)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Time zone set to %s", timezone), nil
+}
+
+func (r *Receiver) reply(message *tgbotapi.Message, text string) {
+	config := tgbotapi.NewMessage(message.Chat.ID, text)
+	config.ReplyToMessageID = message.MessageID
+
+	if _, err := r.bot.Send(config); err != nil {
+		r.log.Error("Failed to reply to bot command", "error", err, "chat_id", message.Chat.ID)
+	}
+}
+
+// formatDate показывает дату отправки в часовом поясе уведомления.
+func formatDate(notification *models.Notification) string {
+	return datetime.In(notification.Date, notification.Timezone).Format("Mon 02.01 15:04")
+}
+
+// shorten обрезает текст напоминания до listTextLength символов и склеивает строки.
+func shorten(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= listTextLength {
+		return text
+	}
+
+	return string([]rune(text)[:listTextLength-1]) + "…"
+}
@@ -0,0 +1,179 @@
+package receiver
+
+import (
+	"DelayedNotifier/internal/config"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
+	"DelayedNotifier/internal/storage"
+	"DelayedNotifier/internal/telegram/receiver/mocks"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/Syfaro/telegram-bot-api"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const chatID = int64(1001)
+
+// commandMessage собирает сообщение с командой так, как его присылает Telegram.
+func commandMessage(text string) *tgbotapi.Message {
+	command := strings.SplitN(text, " ", 2)[0]
+
+	return &tgbotapi.Message{
+		MessageID: 1,
+		Chat:      &tgbotapi.Chat{ID: chatID},
+		Text:      text,
+		Entities:  &[]tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(command)}},
+	}
+}
+
+func newReceiver(svc Service) *Receiver {
+	return New(nil, svc, slog.Default(), config.Telegram{})
+}
+
+func TestCommand_Remind(t *testing.T) {
+	svc := new(mocks.Service)
+	svc.On("CreateChatReminder", chatID, "in 15 minutes call Alice").Return(&models.Notification{
+		ID:       5,
+		Date:     time.Date(2025, 8, 9, 6, 0, 0, 0, time.UTC),
+		Timezone: "Europe/Moscow",
+	}, nil)
+
+	text := newReceiver(svc).command(commandMessage("/remind in 15 minutes call Alice"))
+
+	assert.Equal(t, "Reminder #5 set for Sat 09.08 09:00 (Europe/Moscow)", text)
+	svc.AssertExpectations(t)
+}
+
+func TestCommand_RemindUsage(t *testing.T) {
+	svc := new(mocks.Service)
+
+	text := newReceiver(svc).command(commandMessage("/remind"))
+
+	assert.True(t, strings.HasPrefix(text, "Usage: /remind"))
+	svc.AssertNotCalled(t, "CreateChatReminder")
+}
+
+func TestCommand_RemindInvalidInput(t *testing.T) {
+	svc := new(mocks.Service)
+	svc.On("CreateChatReminder", chatID, "someday call Alice").
+		Return(nil, fmt.Errorf("%w: unrecognized time %q", service.ErrInvalidInput, "someday"))
+
+	text := newReceiver(svc).command(commandMessage("/remind someday call Alice"))
+
+	assert.Equal(t, `Sorry, I couldn't understand that: unrecognized time "someday"`, text)
+}
+
+func TestCommand_InternalError(t *testing.T) {
+	svc := new(mocks.Service)
+	svc.On("ListChatReminders", chatID).Return(nil, errors.New("connection refused"))
+
+	text := newReceiver(svc).command(commandMessage("/list"))
+
+	// Подробности внутренней ошибки в чат не попадают.
+	assert.Equal(t, "Something went wrong, please try again", text)
+}
+
+func TestCommand_List(t *testing.T) {
+	svc := new(mocks.Service)
+	svc.On("ListChatReminders", chatID).Return([]models.Notification{{
+		ID:       7,
+		Date:     time.Date(2025, 8, 9, 6, 0, 0, 0, time.UTC),
+		Timezone: "UTC",
+		Text:     "standup",
+	}}, nil)
+
+	text := newReceiver(svc).command(commandMessage("/list"))
+
+	assert.Equal(t, "Upcoming reminders:\n\n#7 — Sat 09.08 06:00 — standup", text)
+	svc.AssertExpectations(t)
+}
+
+func TestCommand_ListEmpty(t *testing.T) {
+	svc := new(mocks.Service)
+	svc.On("ListChatReminders", chatID).Return(nil, nil)
+
+	text := newReceiver(svc).command(commandMessage("/list"))
+
+	assert.True(t, strings.HasPrefix(text, "You have no upcoming reminders"))
+}
+
+func TestCommand_Cancel(t *testing.T) {
+	svc := new(mocks.Service)
+	svc.On("CancelChatReminder", chatID, int64(5)).Return(nil)
+
+	text := newReceiver(svc).command(commandMessage("/cancel #5"))
+
+	assert.Equal(t, "Reminder #5 cancelled", text)
+	svc.AssertExpectations(t)
+}
+
+func TestCommand_CancelAnotherChatsReminder(t *testing.T) {
+	// Чужое напоминание сервис не отличает от несуществующего.
+	svc := new(mocks.Service)
+	svc.On("CancelChatReminder", chatID, int64(5)).Return(storage.ErrNotifyNotFound)
+
+	text := newReceiver(svc).command(commandMessage("/cancel 5"))
+
+	assert.Equal(t, "Reminder #5 not found among your upcoming reminders", text)
+	svc.AssertExpectations(t)
+}
+
+func TestCommand_CancelUsage(t *testing.T) {
+	svc := new(mocks.Service)
+
+	for _, input := range []string{"/cancel", "/cancel abc", "/cancel -3"} {
+		text := newReceiver(svc).command(commandMessage(input))
+		assert.Equal(t, "Usage: /cancel <id>, see /list for reminder IDs", text, input)
+	}
+	svc.AssertNotCalled(t, "CancelChatReminder")
+}
+
+func TestCommand_Timezone(t *testing.T) {
+	svc := new(mocks.Service)
+	svc.On("SetChatTimezone", chatID, "Europe/Moscow").Return("Europe/Moscow", nil)
+	svc.On("SetChatTimezone", chatID, "Mars/Olympus").
+		Return("", fmt.Errorf("%w: unknown time zone Mars/Olympus", service.ErrInvalidInput))
+
+	r := newReceiver(svc)
+
+	assert.Equal(t, "Time zone set to Europe/Moscow", r.command(commandMessage("/tz Europe/Moscow")))
+	assert.Equal(t, "Sorry, I couldn't understand that: unknown time zone Mars/Olympus", r.command(commandMessage("/tz Mars/Olympus")))
+}
+
+func TestCommand_StartInvalidToken(t *testing.T) {
+	svc := new(mocks.Service)
+	svc.On("BindRecipient", "expired", chatID).Return(nil, storage.ErrRecipientNotFound)
+
+	text := newReceiver(svc).command(commandMessage("/start expired"))
+
+	assert.Equal(t, "This link is invalid or has expired. Please ask for a new one.", text)
+}
+
+func TestCommand_Unknown(t *testing.T) {
+	text := newReceiver(new(mocks.Service)).command(commandMessage("/frobnicate"))
+
+	assert.True(t, strings.HasPrefix(text, "Unknown command."))
+}
+
+func TestShorten(t *testing.T) {
+	assert.Equal(t, "call Alice about the report", shorten("call Alice\nabout  the report"))
+
+	long := strings.Repeat("я", listTextLength+10)
+	short := shorten(long)
+	assert.Equal(t, listTextLength, len([]rune(short)))
+	assert.True(t, strings.HasSuffix(short, "…"))
+}
+
+func TestFormatDate(t *testing.T) {
+	notification := &models.Notification{
+		Date:     time.Date(2025, 8, 9, 6, 0, 0, 0, time.UTC),
+		Timezone: "Europe/Moscow",
+	}
+
+	assert.Equal(t, "Sat 09.08 09:00", formatDate(notification))
+}
@@ -0,0 +1,251 @@
+package receiver
+
+import (
+	"DelayedNotifier/internal/config"
+	"DelayedNotifier/internal/lib/datetime"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/service"
+	"DelayedNotifier/internal/storage"
+	"DelayedNotifier/internal/telegram/actions"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/Syfaro/telegram-bot-api"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// secretHeader — заголовок, в котором Telegram повторяет secret_token, переданный в setWebhook.
+const secretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// allowedUpdates — типы обновлений, которые бот запрашивает у Telegram.
+const allowedUpdates = `["message", "callback_query"]`
+
+// Service — операции сервиса, которые выполняют кнопки и команды бота.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=Service
+type Service interface {
+	AcknowledgeNotification(notificationID, chatID int64) error
+	SnoozeNotification(notificationID, chatID int64, delay time.Duration) (*models.Notification, error)
+	BindRecipient(token string, chatID int64) (*models.Recipient, error)
+	CreateChatReminder(chatID int64, input string) (*models.Notification, error)
+	ListChatReminders(chatID int64) ([]models.Notification, error)
+	CancelChatReminder(chatID, notificationID int64) error
+	ChatTimezone(chatID int64) (string, error)
+	SetChatTimezone(chatID int64, zone string) (string, error)
+	MarkChatReachable(chatID int64) error
+}
+
+// Receiver принимает обновления бота — long polling или webhook — и обрабатывает
+// нажатия кнопок под доставленными уведомлениями и команды бота.
+type Receiver struct {
+	bot     *tgbotapi.BotAPI
+	service Service
+	log     *slog.Logger
+	cfg     config.Telegram
+}
+
+func New(bot *tgbotapi.BotAPI, service Service, log *slog.Logger, cfg config.Telegram) *Receiver {
+	return &Receiver{
+		bot:     bot,
+		service: service,
+		log:     log,
+		cfg:     cfg,
+	}
+}
+
+// Poll получает обновления через long polling, пока не отменён ctx.
+func (r *Receiver) Poll(ctx context.Context) {
+	r.log.Info("Starting Telegram long polling", slog.Int("timeout", r.cfg.PollTimeout))
+
+	// getUpdates не работает, пока у бота установлен webhook.
+	if _, err := r.bot.RemoveWebhook(); err != nil {
+		r.log.Error("Failed to remove Telegram webhook", "error", err)
+	}
+
+	updateConfig := tgbotapi.NewUpdate(0)
+	updateConfig.Timeout = r.cfg.PollTimeout
+
+	updates, err := r.bot.GetUpdatesChan(updateConfig)
+	if err != nil {
+		r.log.Error("Failed to start Telegram long polling", "error", err)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.bot.StopReceivingUpdates()
+			r.log.Info("Telegram long polling stopped")
+			return
+		case update := <-updates:
+			r.handle(update)
+		}
+	}
+}
+
+// SetWebhook регистрирует WebhookURL в Telegram и возвращает путь, на котором
+// HTTP-сервер должен принимать обновления.
+func (r *Receiver) SetWebhook() (string, error) {
+	link, err := url.Parse(r.cfg.WebhookURL)
+	if err != nil || link.Scheme != "https" || link.Host == "" {
+		return "", fmt.Errorf("telegram webhook_url must be an https URL, got %q", r.cfg.WebhookURL)
+	}
+
+	// Без секрета любой, кто узнал путь, мог бы присылать поддельные нажатия и команды, в том числе /start с токеном привязки.
+	if r.cfg.WebhookSecret == "" {
+		return "", fmt.Errorf("telegram webhook_secret is required in webhook mode")
+	}
+
+	params := url.Values{}
+	params.Set("url", link.String())
+	params.Set("allowed_updates", allowedUpdates)
+	params.Set("secret_token", r.cfg.WebhookSecret)
+
+	if _, err = r.bot.MakeRequest("setWebhook", params); err != nil {
+		return "", fmt.Errorf("failed to set Telegram webhook: %w", err)
+	}
+
+	r.log.Info("Telegram webhook configured", slog.String("path", link.Path))
+
+	if link.Path == "" {
+		return "/", nil
+	}
+
+	return link.Path, nil
+}
+
+// Webhook принимает обновления, которые Telegram присылает на WebhookURL.
+func (r *Receiver) Webhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		secret := req.Header.Get(secretHeader)
+		if r.cfg.WebhookSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(r.cfg.WebhookSecret)) != 1 {
+			r.log.Warn("Telegram webhook request with invalid secret")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var update tgbotapi.Update
+		if err := json.NewDecoder(req.Body).Decode(&update); err != nil {
+			r.log.Error("Failed to decode Telegram update", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		r.handle(update)
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (r *Receiver) handle(update tgbotapi.Update) {
+	switch {
+	case update.CallbackQuery != nil:
+		r.handleCallback(update.CallbackQuery)
+	case update.Message != nil && update.Message.IsCommand():
+		r.handleCommand(update.Message)
+	}
+}
+
+// handleCallback выполняет действие кнопки и заменяет кнопки под сообщением подписью с результатом.
+func (r *Receiver) handleCallback(query *tgbotapi.CallbackQuery) {
+	log := r.log.With(slog.String("callback_id", query.ID))
+
+	action, err := actions.Parse(query.Data)
+	if err == nil && action.Kind == actions.KindSnooze && !r.snoozeAllowed(action.Delay) {
+		err = fmt.Errorf("%w: snooze for %s is not configured", actions.ErrInvalidData, action.Delay)
+	}
+	if err != nil {
+		log.Warn("Unknown callback data", "error", err)
+		r.answer(query, "Unknown action")
+		return
+	}
+
+	if action.Kind == actions.KindNoop || query.Message == nil {
+		r.answer(query, "")
+		return
+	}
+
+	chatID := query.Message.Chat.ID
+	log = log.With(
+		slog.Int64("notification_id", action.NotificationID),
+		slog.Int64("chat_id", chatID),
+		slog.String("action", action.Kind),
+	)
+
+	var result string
+	switch action.Kind {
+	case actions.KindAck:
+		err = r.service.AcknowledgeNotification(action.NotificationID, chatID)
+		result = "✅ Done"
+	case actions.KindSnooze:
+		snoozed, snoozeErr := r.service.SnoozeNotification(action.NotificationID, chatID, action.Delay)
+		if err = snoozeErr; err == nil {
+			result = "⏰ Snoozed until " + formatTime(datetime.In(snoozed.Date, snoozed.Timezone))
+			log = log.With(slog.Int64("snoozed_id", snoozed.ID))
+		}
+	}
+
+	switch {
+	case errors.Is(err, storage.ErrNotifyNotActive):
+		log.Info("Reminder already handled")
+		r.answer(query, "This reminder has already been handled")
+		return
+	case errors.Is(err, storage.ErrNotifyNotFound):
+		log.Info("Reminder not found")
+		r.answer(query, "This reminder no longer exists")
+		r.editKeyboard(query.Message, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
+		return
+	case errors.Is(err, service.ErrNotOwner):
+		log.Warn("Reminder action from another chat")
+		r.answer(query, "This reminder belongs to another chat")
+		return
+	case err != nil:
+		log.Error("Failed to handle reminder action", "error", err)
+		r.answer(query, "Something went wrong, please try again")
+		return
+	}
+
+	log.Info("Reminder action handled")
+
+	r.answer(query, result)
+	r.editKeyboard(query.Message, actions.Result(result))
+}
+
+func (r *Receiver) snoozeAllowed(delay time.Duration) bool {
+	for _, allowed := range r.cfg.Snooze {
+		if delay == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// answer убирает индикатор загрузки на кнопке и показывает text всплывающей подсказкой.
+func (r *Receiver) answer(query *tgbotapi.CallbackQuery, text string) {
+	if _, err := r.bot.AnswerCallbackQuery(tgbotapi.NewCallback(query.ID, text)); err != nil {
+		r.log.Error("Failed to answer callback query", "error", err, "callback_id", query.ID)
+	}
+}
+
+// editKeyboard заменяет кнопки под сообщением, не трогая его текст и разметку.
+func (r *Receiver) editKeyboard(message *tgbotapi.Message, keyboard tgbotapi.InlineKeyboardMarkup) {
+	edit := tgbotapi.NewEditMessageReplyMarkup(message.Chat.ID, message.MessageID, keyboard)
+	if _, err := r.bot.Send(edit); err != nil {
+		r.log.Error("Failed to edit reminder message", "error", err, "message_id", message.MessageID)
+	}
+}
+
+// formatTime показывает только время, если момент сегодня, иначе дату и время.
+func formatTime(t time.Time) string {
+	if now := time.Now().In(t.Location()); now.YearDay() == t.YearDay() && now.Year() == t.Year() {
+		return t.Format("15:04")
+	}
+
+	return t.Format("02.01 15:04")
+}
@@ -0,0 +1,51 @@
+package receiver
+
+import (
+	"DelayedNotifier/internal/config"
+	"DelayedNotifier/internal/telegram/receiver/mocks"
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetWebhook_RequiresSecret(t *testing.T) {
+	r := New(nil, new(mocks.Service), slog.Default(), config.Telegram{WebhookURL: "https://example.com/telegram"})
+
+	_, err := r.SetWebhook()
+	assert.ErrorContains(t, err, "webhook_secret is required")
+}
+
+func TestWebhook_Secret(t *testing.T) {
+	cases := []struct {
+		name       string
+		configured string
+		header     string
+		want       int
+	}{
+		{name: "valid", configured: "s3cret", header: "s3cret", want: http.StatusOK},
+		{name: "missing header", configured: "s3cret", want: http.StatusUnauthorized},
+		{name: "wrong header", configured: "s3cret", header: "guess", want: http.StatusUnauthorized},
+		// Пустой секрет в конфигурации не отключает проверку.
+		{name: "no secret configured", want: http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(nil, new(mocks.Service), slog.Default(), config.Telegram{WebhookSecret: tc.configured})
+
+			req := httptest.NewRequest(http.MethodPost, "/telegram", bytes.NewBufferString(`{"update_id": 1}`))
+			if tc.header != "" {
+				req.Header.Set(secretHeader, tc.header)
+			}
+
+			rr := httptest.NewRecorder()
+			r.Webhook().ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.want, rr.Code)
+		})
+	}
+}
@@ -1,72 +1,200 @@
 package worker
 
 import (
-	"DelayedNotifier/internal/service"
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/storage"
+	"errors"
+	"hash/fnv"
 	"log/slog"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/streadway/amqp"
 )
 
+// Service — операции сервиса, которые нужны воркеру для обработки сообщения.
+type Service interface {
+	GetNotificationByID(notificationID int64) (*models.Notification, error)
+	DeadLetter(message []byte, reason string) error
+	ScheduleNotification(notification *models.Notification) error
+	DeferNotification(notificationID int64, delay time.Duration) error
+	ClaimNotification(notificationID int64) (bool, error)
+	DeliverNotification(notification *models.Notification) error
+}
+
+// retryDelay — через сколько сообщение возвращается в очередь, если его не удалось обработать
+// из-за ошибки базы. Немедленный возврат при недоступной базе гонял бы сообщения по кругу.
+const retryDelay = 30 * time.Second
+
 type Worker struct {
-	service *service.Service // Используем сервис
+	service Service // Используем сервис
 	log     *slog.Logger
+	// workers — число горутин отправки, queueSize — очередь каждой из них.
+	workers   int
+	queueSize int
+}
+
+// job — полученное сообщение и уведомление, на которое оно ссылается.
+type job struct {
+	delivery     amqp.Delivery
+	notification *models.Notification
 }
 
-func New(service *service.Service, log *slog.Logger) *Worker {
+func New(service Service, log *slog.Logger, workers, queueSize int) *Worker {
+	if workers < 1 {
+		workers = 1
+	}
+
 	return &Worker{
-		service: service,
-		log:     log,
+		service:   service,
+		log:       log,
+		workers:   workers,
+		queueSize: queueSize,
 	}
 }
 
+// Start раздаёт сообщения горутинам отправки, пока не закрыт msgs.
+// Уведомления одного получателя всегда попадают в одну горутину и отправляются в порядке
+// поступления, поэтому медленный чат задерживает только своих соседей по горутине.
+// Каждое сообщение подтверждается отдельно после обработки.
 func (w *Worker) Start(msgs <-chan amqp.Delivery) {
-	w.log.Info("Starting RabbitMQ worker")
+	w.log.Info("Starting RabbitMQ worker", slog.Int("workers", w.workers))
 
-	for d := range msgs {
-		notificationID, err := strconv.ParseInt(string(d.Body), 10, 64)
-		if err != nil {
-			w.log.Error("Failed to parse notification ID", "error", err)
-			err = d.Ack(false)
-			if err != nil {
-				return
+	queues := make([]chan job, w.workers)
+
+	var wg sync.WaitGroup
+	for i := range queues {
+		queues[i] = make(chan job, w.queueSize)
+
+		wg.Add(1)
+		go func(jobs <-chan job) {
+			defer wg.Done()
+
+			for j := range jobs {
+				w.process(j.delivery, j.notification)
 			}
+		}(queues[i])
+	}
+
+	for d := range msgs {
+		notification, ok := w.load(d)
+		if !ok {
 			continue
 		}
 
-		notification, err := w.service.GetNotificationByID(notificationID)
+		queues[shard(notification, len(queues))] <- job{delivery: d, notification: notification}
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+
+	w.log.Info("RabbitMQ worker stopped")
+}
+
+// load читает уведомление из сообщения. Сообщение, которое не удалось разобрать
+// или которое ссылается на несуществующее уведомление, подтверждается сразу;
+// при ошибке базы сообщение откладывается на retryDelay.
+func (w *Worker) load(d amqp.Delivery) (*models.Notification, bool) {
+	notificationID, err := strconv.ParseInt(string(d.Body), 10, 64)
+	if err != nil {
+		w.log.Error("Failed to parse notification ID", "error", err)
+		err = w.service.DeadLetter(d.Body, "invalid notification ID: "+err.Error())
 		if err != nil {
-			w.log.Error("Failed to get notification by ID", "error", err, "notification_id", notificationID)
-			err = d.Ack(false)
-			if err != nil {
-				return
-			}
-			continue
+			w.log.Error("Failed to dead-letter message", "error", err)
 		}
+		w.ack(d, 0)
+		return nil, false
+	}
 
-		if time.Now().After(notification.Date) {
-			err = w.service.SendNotification(notification.RecipientID, notification.Text)
-			newStatus := "sent"
-			if err != nil {
-				newStatus = "failed"
-				w.log.Error("Failed to send message to Telegram", "error", err, "notification_id", notificationID)
-			}
+	notification, err := w.service.GetNotificationByID(notificationID)
+	if errors.Is(err, storage.ErrNotifyNotFound) {
+		w.log.Error("Failed to get notification by ID", "error", err, "notification_id", notificationID)
+		w.ack(d, notificationID)
+		return nil, false
+	}
+	if err != nil {
+		w.log.Error("Failed to get notification by ID", "error", err, "notification_id", notificationID)
+		w.retryLater(d, notificationID)
+		return nil, false
+	}
 
-			err = w.service.UpdateNotificationStatus(notificationID, newStatus)
-			if err != nil {
-				return
-			}
-			err = d.Ack(false)
-			if err != nil {
-				return
-			}
-		} else {
-			err = d.Nack(false, true)
-			if err != nil {
-				return
-			}
+	return notification, true
+}
+
+func (w *Worker) process(d amqp.Delivery, notification *models.Notification) {
+	notificationID := notification.ID
+
+	if time.Now().Before(notification.DueAt()) {
+		err := w.service.ScheduleNotification(notification)
+		if err != nil {
+			w.log.Error("Failed to reschedule notification", "error", err, "notification_id", notificationID)
+			w.nack(d, notificationID)
+			return
 		}
+		w.ack(d, notificationID)
+		return
 	}
-	w.log.Info("RabbitMQ worker stopped")
+
+	claimed, err := w.service.ClaimNotification(notificationID)
+	if err != nil {
+		w.log.Error("Failed to claim notification", "error", err, "notification_id", notificationID)
+		w.retryLater(d, notificationID)
+		return
+	}
+
+	if !claimed {
+		w.log.Info("Notification already processed, skipping", "notification_id", notificationID)
+		w.ack(d, notificationID)
+		return
+	}
+
+	err = w.service.DeliverNotification(notification)
+	if err != nil {
+		w.log.Error("Failed to deliver notification", "error", err, "notification_id", notificationID)
+	}
+
+	w.ack(d, notificationID)
+}
+
+// retryLater откладывает сообщение на retryDelay. Если отложить не удалось, сообщение
+// возвращается в очередь, чтобы не потерять его.
+func (w *Worker) retryLater(d amqp.Delivery, notificationID int64) {
+	if err := w.service.DeferNotification(notificationID, retryDelay); err != nil {
+		w.log.Error("Failed to defer notification", "error", err, "notification_id", notificationID)
+		w.nack(d, notificationID)
+		return
+	}
+
+	w.ack(d, notificationID)
+}
+
+// Ошибка подтверждения означает закрытый канал: RabbitMQ вернёт сообщение в очередь сам.
+func (w *Worker) ack(d amqp.Delivery, notificationID int64) {
+	if err := d.Ack(false); err != nil {
+		w.log.Error("Failed to ack message", "error", err, "notification_id", notificationID)
+	}
+}
+
+func (w *Worker) nack(d amqp.Delivery, notificationID int64) {
+	if err := d.Nack(false, true); err != nil {
+		w.log.Error("Failed to nack message", "error", err, "notification_id", notificationID)
+	}
+}
+
+// shard выбирает горутину по получателю текущего канала уведомления.
+func shard(notification *models.Notification, workers int) int {
+	route := notification.Route()
+
+	recipient := route.Address
+	if recipient == "" {
+		recipient = strconv.FormatInt(notification.RecipientID, 10)
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(route.Channel + ":" + recipient))
+
+	return int(h.Sum32() % uint32(workers))
 }
@@ -0,0 +1,308 @@
+package worker
+
+import (
+	"DelayedNotifier/internal/models"
+	"DelayedNotifier/internal/storage"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeService хранит уведомления в памяти и запоминает, в каком порядке и сколько одновременно
+// их отправляли. Отправка занимает delay.
+type fakeService struct {
+	delay    time.Duration
+	getErr   error
+	claimErr error
+	deferErr error
+
+	mu            sync.Mutex
+	notifications map[int64]*models.Notification
+	delivered     []int64
+	deferred      []int64
+	deadLetters   [][]byte
+	running       int
+	maxRunning    int
+}
+
+func newFakeService(delay time.Duration, notifications ...*models.Notification) *fakeService {
+	s := &fakeService{
+		delay:         delay,
+		notifications: make(map[int64]*models.Notification),
+	}
+	for _, n := range notifications {
+		s.notifications[n.ID] = n
+	}
+
+	return s
+}
+
+func (s *fakeService) GetNotificationByID(notificationID int64) (*models.Notification, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.notifications[notificationID]
+	if !ok {
+		return nil, storage.ErrNotifyNotFound
+	}
+	copied := *n
+
+	return &copied, nil
+}
+
+func (s *fakeService) DeadLetter(message []byte, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deadLetters = append(s.deadLetters, message)
+
+	return nil
+}
+
+func (s *fakeService) ScheduleNotification(*models.Notification) error {
+	return nil
+}
+
+func (s *fakeService) DeferNotification(notificationID int64, _ time.Duration) error {
+	if s.deferErr != nil {
+		return s.deferErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deferred = append(s.deferred, notificationID)
+
+	return nil
+}
+
+func (s *fakeService) ClaimNotification(int64) (bool, error) {
+	return s.claimErr == nil, s.claimErr
+}
+
+func (s *fakeService) DeliverNotification(notification *models.Notification) error {
+	s.mu.Lock()
+	s.running++
+	s.maxRunning = max(s.maxRunning, s.running)
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	s.running--
+	s.delivered = append(s.delivered, notification.ID)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// isProcessed сообщает, отправлено или отложено ли уведомление.
+func (s *fakeService) isProcessed(notificationID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range append(s.delivered, s.deferred...) {
+		if id == notificationID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acknowledger записывает подтверждения сообщений; DeliveryTag сообщения равен ID уведомления.
+type acknowledger struct {
+	service *fakeService
+
+	mu sync.Mutex
+	// acked — подтверждённые сообщения, early — подтверждённые раньше, чем уведомление отправлено или отложено.
+	acked  []uint64
+	early  []uint64
+	nacked []uint64
+}
+
+func (a *acknowledger) Ack(tag uint64, _ bool) error {
+	processed := a.service.isProcessed(int64(tag))
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.acked = append(a.acked, tag)
+	if !processed {
+		a.early = append(a.early, tag)
+	}
+
+	return nil
+}
+
+func (a *acknowledger) Nack(tag uint64, _ bool, _ bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.nacked = append(a.nacked, tag)
+
+	return nil
+}
+
+func (a *acknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// run отдаёт воркеру сообщения с указанными ID и ждёт, пока он обработает их все.
+func run(w *Worker, ack *acknowledger, bodies ...string) {
+	msgs := make(chan amqp.Delivery)
+
+	done := make(chan struct{})
+	go func() {
+		w.Start(msgs)
+		close(done)
+	}()
+
+	for _, body := range bodies {
+		tag, _ := strconv.ParseUint(body, 10, 64)
+		msgs <- amqp.Delivery{Acknowledger: ack, DeliveryTag: tag, Body: []byte(body)}
+	}
+	close(msgs)
+
+	<-done
+}
+
+func TestWorker_OrderPerRecipient(t *testing.T) {
+	var (
+		notifications []*models.Notification
+		bodies        []string
+	)
+	// Уведомления трёх получателей вперемешку: ID растут в порядке поступления.
+	for id := int64(1); id <= 15; id++ {
+		notifications = append(notifications, &models.Notification{ID: id, RecipientID: 100 + id%3, Channel: "telegram"})
+		bodies = append(bodies, strconv.FormatInt(id, 10))
+	}
+
+	svc := newFakeService(2*time.Millisecond, notifications...)
+	ack := &acknowledger{service: svc}
+
+	run(New(svc, slog.Default(), 2, 4), ack, bodies...)
+
+	require.Len(t, svc.delivered, 15)
+
+	last := make(map[int64]int64)
+	for _, id := range svc.delivered {
+		recipient := 100 + id%3
+		assert.Greater(t, id, last[recipient], "notification %d delivered out of order", id)
+		last[recipient] = id
+	}
+
+	assert.Len(t, ack.acked, 15)
+	assert.Empty(t, ack.early, "messages acked before delivery")
+	assert.Empty(t, ack.nacked)
+}
+
+func TestWorker_BoundedConcurrency(t *testing.T) {
+	var (
+		notifications []*models.Notification
+		bodies        []string
+	)
+	for id := int64(1); id <= 30; id++ {
+		notifications = append(notifications, &models.Notification{ID: id, RecipientID: id, Channel: "telegram"})
+		bodies = append(bodies, strconv.FormatInt(id, 10))
+	}
+
+	svc := newFakeService(10*time.Millisecond, notifications...)
+	ack := &acknowledger{service: svc}
+
+	run(New(svc, slog.Default(), 3, 32), ack, bodies...)
+
+	assert.Len(t, svc.delivered, 30)
+	assert.LessOrEqual(t, svc.maxRunning, 3)
+	assert.Greater(t, svc.maxRunning, 1, "different recipients should be delivered in parallel")
+	assert.Empty(t, ack.early)
+}
+
+func TestWorker_UnknownAndInvalidMessages(t *testing.T) {
+	svc := newFakeService(0)
+	ack := &acknowledger{service: svc}
+
+	run(New(svc, slog.Default(), 2, 4), ack, "42", "not-a-number")
+
+	// Оба сообщения подтверждены, чтобы не возвращаться в очередь; неразобранное ушло в DLQ.
+	assert.Len(t, ack.acked, 2)
+	assert.Equal(t, [][]byte{[]byte("not-a-number")}, svc.deadLetters)
+	assert.Empty(t, svc.delivered)
+}
+
+func TestWorker_DatabaseError(t *testing.T) {
+	dbErr := errors.New("database is down")
+
+	cases := []struct {
+		name     string
+		getErr   error
+		claimErr error
+		deferErr error
+		deferred []int64
+		acked    []uint64
+		nacked   []uint64
+	}{
+		// Сообщение откладывается, а не возвращается в очередь сразу: иначе при недоступной базе
+		// оно приходило бы снова без паузы.
+		{name: "claim", claimErr: dbErr, deferred: []int64{7}, acked: []uint64{7}},
+		{name: "load", getErr: dbErr, deferred: []int64{7}, acked: []uint64{7}},
+		// Если отложить не удалось, сообщение возвращается в очередь, чтобы не потерять его.
+		{name: "defer failed", claimErr: dbErr, deferErr: errors.New("rabbitmq is unavailable"), nacked: []uint64{7}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := newFakeService(0, &models.Notification{ID: 7, RecipientID: 1, Channel: "telegram"})
+			svc.getErr = tc.getErr
+			svc.claimErr = tc.claimErr
+			svc.deferErr = tc.deferErr
+			ack := &acknowledger{service: svc}
+
+			run(New(svc, slog.Default(), 2, 4), ack, "7")
+
+			assert.Equal(t, tc.deferred, svc.deferred)
+			assert.Equal(t, tc.acked, ack.acked)
+			assert.Equal(t, tc.nacked, ack.nacked)
+			assert.Empty(t, ack.early)
+			assert.Empty(t, svc.delivered)
+		})
+	}
+}
+
+func TestShard_SameRecipient(t *testing.T) {
+	first := &models.Notification{ID: 1, RecipientID: 123, Channel: "telegram"}
+	second := &models.Notification{ID: 2, RecipientID: 123, Channel: "telegram"}
+
+	assert.Equal(t, shard(first, 8), shard(second, 8))
+}
+
+func TestShard_FallbackRoute(t *testing.T) {
+	notification := &models.Notification{
+		RecipientID: 123,
+		Channel:     "telegram",
+		Fallback:    []models.Route{{Channel: "email", Address: "alice@example.com"}},
+		RouteIndex:  1,
+	}
+	byAddress := &models.Notification{Channel: "email", Address: "alice@example.com"}
+
+	assert.Equal(t, shard(byAddress, 8), shard(notification, 8))
+}
+
+func TestShard_Range(t *testing.T) {
+	for id := int64(1); id <= 100; id++ {
+		n := shard(&models.Notification{RecipientID: id, Channel: "telegram"}, 3)
+		assert.GreaterOrEqual(t, n, 0)
+		assert.Less(t, n, 3)
+	}
+}
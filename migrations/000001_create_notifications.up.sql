@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS notifications
+(
+    id           BIGSERIAL PRIMARY KEY,
+    recipient_id BIGINT      NOT NULL,
+    date         TIMESTAMPTZ NOT NULL,
+    text         TEXT        NOT NULL,
+    status       VARCHAR(32) NOT NULL DEFAULT 'pending'
+);
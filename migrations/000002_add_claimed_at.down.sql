@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS notifications_due_idx;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS claimed_at;
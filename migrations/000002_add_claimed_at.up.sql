@@ -0,0 +1,4 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS notifications_due_idx ON notifications (date) WHERE status IN ('pending', 'in_flight');
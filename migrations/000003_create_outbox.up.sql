@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id              BIGSERIAL PRIMARY KEY,
+    notification_id BIGINT      NOT NULL REFERENCES notifications (id) ON DELETE CASCADE,
+    deliver_at      TIMESTAMPTZ NOT NULL,
+    attempts        INT         NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    dispatched_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS outbox_undispatched_idx ON outbox (next_attempt_at) WHERE dispatched_at IS NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS attempts        INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS last_error      TEXT;
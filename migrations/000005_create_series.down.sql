@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS notifications_series_date_idx;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS series;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS series
+(
+    id           BIGSERIAL PRIMARY KEY,
+    recipient_id BIGINT      NOT NULL,
+    text         TEXT        NOT NULL,
+    kind         VARCHAR(16) NOT NULL,
+    expression   TEXT        NOT NULL,
+    start_at     TIMESTAMPTZ NOT NULL,
+    until        TIMESTAMPTZ,
+    max_count    INT         NOT NULL DEFAULT 0,
+    occurrences  INT         NOT NULL DEFAULT 0,
+    active       BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS series_id BIGINT REFERENCES series (id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS notifications_series_date_idx ON notifications (series_id, date) WHERE series_id IS NOT NULL;
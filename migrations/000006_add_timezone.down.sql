@@ -0,0 +1,5 @@
+ALTER TABLE series
+    DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS timezone;
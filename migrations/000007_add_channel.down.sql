@@ -0,0 +1,7 @@
+ALTER TABLE series
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS channel;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS channel;
@@ -0,0 +1,7 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS channel VARCHAR(32)  NOT NULL DEFAULT 'telegram',
+    ADD COLUMN IF NOT EXISTS address VARCHAR(320) NOT NULL DEFAULT '';
+
+ALTER TABLE series
+    ADD COLUMN IF NOT EXISTS channel VARCHAR(32)  NOT NULL DEFAULT 'telegram',
+    ADD COLUMN IF NOT EXISTS address VARCHAR(320) NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+ALTER TABLE series
+    DROP COLUMN IF EXISTS metadata;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS metadata;
@@ -0,0 +1,5 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS metadata JSONB;
+
+ALTER TABLE series
+    ADD COLUMN IF NOT EXISTS metadata JSONB;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS notification_events;
+
+ALTER TABLE series
+    DROP COLUMN IF EXISTS fallback;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS route_index,
+    DROP COLUMN IF EXISTS fallback;
@@ -0,0 +1,20 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS fallback    JSONB,
+    ADD COLUMN IF NOT EXISTS route_index INT NOT NULL DEFAULT 0;
+
+ALTER TABLE series
+    ADD COLUMN IF NOT EXISTS fallback JSONB;
+
+CREATE TABLE IF NOT EXISTS notification_events
+(
+    id              BIGSERIAL PRIMARY KEY,
+    notification_id BIGINT      NOT NULL REFERENCES notifications (id) ON DELETE CASCADE,
+    event           VARCHAR(32) NOT NULL,
+    status          VARCHAR(32) NOT NULL,
+    channel         VARCHAR(32) NOT NULL,
+    attempt         INT         NOT NULL DEFAULT 0,
+    error           TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS notification_events_notification_idx ON notification_events (notification_id, id);
@@ -0,0 +1,9 @@
+ALTER TABLE series
+    DROP COLUMN IF EXISTS disable_notification,
+    DROP COLUMN IF EXISTS disable_web_page_preview,
+    DROP COLUMN IF EXISTS parse_mode;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS disable_notification,
+    DROP COLUMN IF EXISTS disable_web_page_preview,
+    DROP COLUMN IF EXISTS parse_mode;
@@ -0,0 +1,9 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS parse_mode               VARCHAR(16) NOT NULL DEFAULT 'plain',
+    ADD COLUMN IF NOT EXISTS disable_web_page_preview BOOLEAN     NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS disable_notification     BOOLEAN     NOT NULL DEFAULT FALSE;
+
+ALTER TABLE series
+    ADD COLUMN IF NOT EXISTS parse_mode               VARCHAR(16) NOT NULL DEFAULT 'plain',
+    ADD COLUMN IF NOT EXISTS disable_web_page_preview BOOLEAN     NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS disable_notification     BOOLEAN     NOT NULL DEFAULT FALSE;
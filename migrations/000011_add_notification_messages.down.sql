@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS notification_messages;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS sent_parts;
@@ -0,0 +1,12 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS sent_parts INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS notification_messages
+(
+    notification_id BIGINT      NOT NULL REFERENCES notifications (id) ON DELETE CASCADE,
+    route_index     INT         NOT NULL,
+    part            INT         NOT NULL,
+    message_id      BIGINT      NOT NULL,
+    sent_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (notification_id, route_index, part)
+);
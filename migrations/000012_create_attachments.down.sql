@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS notifications_attachment_idx;
+
+ALTER TABLE series
+    DROP COLUMN IF EXISTS attachment_id;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS attachment_id;
+
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS attachments
+(
+    id           BIGSERIAL PRIMARY KEY,
+    kind         VARCHAR(16)  NOT NULL,
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    size         BIGINT       NOT NULL DEFAULT 0,
+    blob_key     VARCHAR(128),
+    url          TEXT,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS attachments_created_at_idx ON attachments (created_at);
+
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS attachment_id BIGINT REFERENCES attachments (id) ON DELETE SET NULL;
+
+ALTER TABLE series
+    ADD COLUMN IF NOT EXISTS attachment_id BIGINT REFERENCES attachments (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS notifications_attachment_idx ON notifications (attachment_id) WHERE attachment_id IS NOT NULL;
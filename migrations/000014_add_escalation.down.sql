@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS notifications_escalation_of_idx;
+DROP INDEX IF EXISTS notifications_escalate_at_idx;
+
+ALTER TABLE series
+    DROP COLUMN IF EXISTS escalation;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS escalation_of,
+    DROP COLUMN IF EXISTS escalate_at,
+    DROP COLUMN IF EXISTS escalation_level,
+    DROP COLUMN IF EXISTS escalation;
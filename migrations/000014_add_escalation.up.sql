@@ -0,0 +1,11 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS escalation       JSONB,
+    ADD COLUMN IF NOT EXISTS escalation_level INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS escalate_at      TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS escalation_of    BIGINT REFERENCES notifications (id) ON DELETE SET NULL;
+
+ALTER TABLE series
+    ADD COLUMN IF NOT EXISTS escalation JSONB;
+
+CREATE INDEX IF NOT EXISTS notifications_escalate_at_idx ON notifications (escalate_at) WHERE escalate_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS notifications_escalation_of_idx ON notifications (escalation_of) WHERE escalation_of IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS chat_settings;
+
+DROP INDEX IF EXISTS notifications_owner_chat_idx;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS owner_chat_id;
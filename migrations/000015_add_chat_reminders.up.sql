@@ -0,0 +1,11 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS owner_chat_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS notifications_owner_chat_idx ON notifications (owner_chat_id, date) WHERE owner_chat_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS chat_settings
+(
+    chat_id    BIGINT PRIMARY KEY,
+    timezone   VARCHAR(64) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
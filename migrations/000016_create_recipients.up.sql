@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS recipients
+(
+    id               BIGSERIAL PRIMARY KEY,
+    alias            VARCHAR(64) UNIQUE,
+    external_id      VARCHAR(128) UNIQUE,
+    name             TEXT        NOT NULL DEFAULT '',
+    chat_id          BIGINT,
+    active           BOOLEAN     NOT NULL DEFAULT TRUE,
+    onboarding_token VARCHAR(64) UNIQUE,
+    token_expires_at TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (alias IS NOT NULL OR external_id IS NOT NULL)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS recipients_chat_id_idx;
+
+ALTER TABLE recipients
+    DROP COLUMN IF EXISTS deactivated_reason;
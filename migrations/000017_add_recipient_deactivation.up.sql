@@ -0,0 +1,4 @@
+ALTER TABLE recipients
+    ADD COLUMN IF NOT EXISTS deactivated_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS recipients_chat_id_idx ON recipients (chat_id) WHERE chat_id IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS requeued_for TIMESTAMPTZ;
@@ -0,0 +1,4 @@
+ALTER TABLE chat_settings
+    DROP COLUMN IF EXISTS unreachable_reason,
+    DROP COLUMN IF EXISTS unreachable_at,
+    ALTER COLUMN timezone DROP DEFAULT;
@@ -0,0 +1,4 @@
+ALTER TABLE chat_settings
+    ALTER COLUMN timezone SET DEFAULT '',
+    ADD COLUMN IF NOT EXISTS unreachable_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS unreachable_reason TEXT;
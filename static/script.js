@@ -31,7 +31,9 @@ createForm.addEventListener('submit', (e) => {
     const date = document.getElementById('date').value;
     const text = document.getElementById('text').value;
 
-    const body = { recipient_id: recipientID, date, text };
+    const timezone = Intl.DateTimeFormat().resolvedOptions().timeZone;
+
+    const body = { recipient_id: recipientID, date, timezone, text };
     sendRequest(API_URL, 'POST', body);
 });
 